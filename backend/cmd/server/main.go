@@ -1,16 +1,27 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
 	"time"
 
 	"joblog/internal/api"
 	"joblog/internal/api/handler"
+	"joblog/internal/api/middleware"
 	"joblog/internal/core/service"
 	"joblog/internal/repository/postgres"
+	"joblog/internal/worker"
 	"joblog/pkg/auth"
 	"joblog/pkg/database"
+	"joblog/pkg/mail"
+	"joblog/pkg/notify"
 
 	"github.com/joho/godotenv"
 )
@@ -21,6 +32,10 @@ func main() {
 		log.Println("No .env file found, using environment variables")
 	}
 
+	// Cancelled on SIGINT/SIGTERM, which starts the graceful shutdown below
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// |--- Dependency Injection ---
 
 	dbpool, err := database.ConnectDB()
@@ -29,26 +44,133 @@ func main() {
 	}
 	defer dbpool.Close()
 
-	jwtSecret := "my-super-secret-and-long-key-for-hs256"
-	jwtManager := auth.NewJWTManager(jwtSecret, 24*time.Hour)
+	jwtConfig, err := auth.JWTConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Could not configure JWT signing: %v", err)
+	}
+	jwtManager, err := auth.NewJWTManager(jwtConfig)
+	if err != nil {
+		log.Fatalf("Could not configure JWT signing: %v", err)
+	}
+
+	notifier, err := notify.FromEnv()
+	if err != nil {
+		log.Fatalf("Could not configure notifications: %v", err)
+	}
+
+	mailer, err := mail.FromEnv()
+	if err != nil {
+		log.Fatalf("Could not configure email: %v", err)
+	}
+	trustedProxies, err := middleware.ParseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+	// Links in emails open the web app, which in development is the Vite dev server
+	appURL := os.Getenv("APP_URL")
+	if appURL == "" {
+		appURL = "http://localhost:5173"
+	}
+
+	duplicatePolicy := service.DefaultDuplicatePolicy()
+	if v := os.Getenv("DUPLICATE_WINDOW_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			log.Fatalf("Invalid DUPLICATE_WINDOW_DAYS %q", v)
+		}
+		duplicatePolicy.Window = time.Duration(days) * 24 * time.Hour
+	}
 
 	userRepo := postgres.NewUserRepository(dbpool)
+	sessionRepo := postgres.NewSessionRepository(dbpool)
+	userTokenRepo := postgres.NewUserTokenRepository(dbpool)
+	loginAttemptRepo := postgres.NewLoginAttemptRepository(dbpool)
+	rateLimitRepo := postgres.NewRateLimitRepository(dbpool)
 	appRepo := postgres.NewApplicationRepository(dbpool)
 	blogRepo := postgres.NewBlogRepository(dbpool)
+	workflowRepo := postgres.NewWorkflowRepository(dbpool)
+	interviewRepo := postgres.NewInterviewRepository(dbpool)
+	calendarTokenRepo := postgres.NewCalendarTokenRepository(dbpool)
+	reminderRepo := postgres.NewReminderRepository(dbpool)
+	followUpRuleRepo := postgres.NewFollowUpRuleRepository(dbpool)
+	contactRepo := postgres.NewContactRepository(dbpool)
+	companyRepo := postgres.NewCompanyRepository(dbpool)
+	offerRepo := postgres.NewOfferRepository(dbpool)
+	tagRepo := postgres.NewTagRepository(dbpool)
+	customFieldRepo := postgres.NewCustomFieldRepository(dbpool)
+	searchRepo := postgres.NewSearchRepository(dbpool)
 
 	// userRepo := memory.NewUserRepository()
+	// sessionRepo := memory.NewSessionRepository()
+	// userTokenRepo := memory.NewUserTokenRepository()
+	// loginAttemptRepo := memory.NewLoginAttemptRepository()
+	// rateLimitRepo := memory.NewRateLimitRepository()
 	// appRepo := memory.NewApplicationRepository()
 	// blogRepo := memory.NewBlogRepository()
+	// workflowRepo := memory.NewWorkflowRepository()
+	// interviewRepo := memory.NewInterviewRepository()
+	// calendarTokenRepo := memory.NewCalendarTokenRepository()
+	// reminderRepo := memory.NewReminderRepository()
+	// followUpRuleRepo := memory.NewFollowUpRuleRepository()
+	// contactRepo := memory.NewContactRepository()
+	// companyRepo := memory.NewCompanyRepository()
+	// offerRepo := memory.NewOfferRepository()
+	// tagRepo := memory.NewTagRepository()
+	// customFieldRepo := memory.NewCustomFieldRepository()
+	// searchRepo := memory.NewSearchRepository()
 
-	authService := service.NewAuthService(userRepo, jwtManager)
-	appService := service.NewApplicationService(appRepo)
-	blogService := service.NewBlogService(blogRepo)
+	loginGuard := service.NewLoginGuard(loginAttemptRepo, rateLimitRepo, service.DefaultLoginPolicy())
+	authService := service.NewAuthService(userRepo, sessionRepo, userTokenRepo, loginGuard, jwtManager, mailer, appURL)
+	workflowService := service.NewWorkflowService(workflowRepo)
+	appService := service.NewApplicationService(appRepo, workflowService, contactRepo, customFieldRepo, tagRepo, duplicatePolicy)
+	blogService := service.NewBlogService(blogRepo, userRepo)
+	interviewService := service.NewInterviewService(interviewRepo, appService)
+	contactService := service.NewContactService(contactRepo, appService)
+	companyService := service.NewCompanyService(companyRepo, appRepo)
+	offerService := service.NewOfferService(offerRepo, appService)
+	tagService := service.NewTagService(tagRepo, appService)
+	customFieldService := service.NewCustomFieldService(customFieldRepo)
+	searchService := service.NewSearchService(searchRepo)
+	reminderService := service.NewReminderService(reminderRepo, followUpRuleRepo, userRepo, appService, workflowService, notifier)
+	calendarService := service.NewCalendarService(calendarTokenRepo, interviewService, reminderService, offerService)
 
 	authHandler := handler.NewAuthHandler(authService)
 	appHandler := handler.NewApplicationHandler(appService)
 	blogHandler := handler.NewBlogHandler(blogService)
+	workflowHandler := handler.NewWorkflowHandler(workflowService)
+	interviewHandler := handler.NewInterviewHandler(interviewService)
+	calendarHandler := handler.NewCalendarHandler(calendarService)
+	reminderHandler := handler.NewReminderHandler(reminderService)
+	contactHandler := handler.NewContactHandler(contactService)
+	companyHandler := handler.NewCompanyHandler(companyService)
+	offerHandler := handler.NewOfferHandler(offerService)
+	tagHandler := handler.NewTagHandler(tagService)
+	customFieldHandler := handler.NewCustomFieldHandler(customFieldService)
+	searchHandler := handler.NewSearchHandler(searchService)
+	jwksHandler := handler.NewJWKSHandler(jwtManager)
 
-	router := api.NewRouter(authHandler, appHandler, blogHandler, jwtManager)
+	router := api.NewRouter(authHandler, appHandler, blogHandler, workflowHandler, interviewHandler, calendarHandler, reminderHandler, contactHandler, companyHandler, offerHandler, tagHandler, customFieldHandler, searchHandler, jwksHandler, jwtManager, authService,
+		trustedProxies, loginGuard.IPLimiter(), loginGuard.UsernameLimiter(), loginGuard.ForgotPasswordLimiter())
+
+	// |--- Background Workers ---
+	reminderInterval := time.Minute
+	if v := os.Getenv("REMINDER_INTERVAL"); v != "" {
+		if reminderInterval, err = time.ParseDuration(v); err != nil || reminderInterval <= 0 {
+			log.Fatalf("Invalid REMINDER_INTERVAL %q", v)
+		}
+	}
+
+	var workers sync.WaitGroup
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		worker.NewReminderWorker(reminderService, reminderInterval).Run(ctx)
+	}()
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		worker.NewLoginCleanupWorker(loginGuard, time.Hour).Run(ctx)
+	}()
 
 	// |--- Server Configuration ---
 	server := &http.Server{
@@ -59,8 +181,21 @@ func main() {
 		IdleTimeout:  120 * time.Second,
 	}
 
-	log.Println("Server listening on http://localhost:8080")
-	if err := server.ListenAndServe(); err != nil {
-		log.Fatalf("Could not start server: %v\n", err)
+	go func() {
+		log.Println("Server listening on http://localhost:8080")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Could not start server: %v\n", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server shutdown error: %v", err)
 	}
+	workers.Wait()
+	log.Println("Server stopped")
 }
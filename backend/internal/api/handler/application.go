@@ -2,8 +2,12 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"joblog/internal/core/domain"
 	"joblog/internal/core/service"
@@ -20,15 +24,116 @@ func NewApplicationHandler(appService *service.ApplicationService) *ApplicationH
 	return &ApplicationHandler{appService: appService}
 }
 
+// GetAllApplications lists applications, supporting the query parameters
+// status (repeatable or comma-separated), tag (tag names, likewise), include=archived,
+// company, role, from, to, field.<custom field key>, sort (date, updatedAt or company; prefix with "-" for descending), cursor and limit.
+// The next page, if any, is advertised through a Link header with rel="next".
 func (h *ApplicationHandler) GetAllApplications(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(string)
-	apps, err := h.appService.GetAllByUserID(r.Context(), userID)
+
+	filter, err := parseApplicationFilter(r)
+	if err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.appService.List(r.Context(), userID, filter)
 	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
 		log.Println("[AppHandler.GetAllApplications] Error:", err)
 		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not fetch applications")
 		return
 	}
-	jsonutil.RespondWithJSON(w, http.StatusOK, apps)
+
+	if page.NextCursor != nil {
+		next := *r.URL
+		query := next.Query()
+		query.Set("cursor", page.NextCursor.Encode())
+		next.RawQuery = query.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.RequestURI()))
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, page.Applications)
+}
+
+func parseApplicationFilter(r *http.Request) (domain.ApplicationFilter, error) {
+	query := r.URL.Query()
+	filter := domain.ApplicationFilter{
+		Company:  query.Get("company"),
+		Role:     query.Get("role"),
+		DateFrom: query.Get("from"),
+		DateTo:   query.Get("to"),
+	}
+
+	for _, value := range query["status"] {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				filter.Statuses = append(filter.Statuses, domain.ApplicationStatus(status))
+			}
+		}
+	}
+
+	for _, value := range query["tag"] {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				filter.Tags = append(filter.Tags, tag)
+			}
+		}
+	}
+
+	// Custom fields filter as field.<key>=<value>
+	for name, values := range query {
+		if key, ok := strings.CutPrefix(name, "field."); ok && len(values) > 0 {
+			if filter.CustomFields == nil {
+				filter.CustomFields = domain.CustomFieldValues{}
+			}
+			filter.CustomFields[key] = values[0]
+		}
+	}
+
+	for _, value := range query["include"] {
+		for _, include := range strings.Split(value, ",") {
+			if strings.TrimSpace(include) == "archived" {
+				filter.IncludeArchived = true
+			}
+		}
+	}
+
+	if sort := query.Get("sort"); sort != "" {
+		filter.Descending = strings.HasPrefix(sort, "-")
+		filter.Sort = domain.ApplicationSort(strings.TrimPrefix(sort, "-"))
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return filter, fmt.Errorf("limit must be a positive integer")
+		}
+		filter.Limit = n
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		c, err := domain.DecodeApplicationCursor(cursor)
+		if err != nil {
+			return filter, err
+		}
+		filter.Cursor = c
+	}
+
+	return filter, nil
+}
+
+func (h *ApplicationHandler) GetApplicationStats(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	stats, err := h.appService.GetStats(r.Context(), userID)
+	if err != nil {
+		log.Println("[AppHandler.GetApplicationStats] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not compute application statistics")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, stats)
 }
 
 func (h *ApplicationHandler) CreateApplication(w http.ResponseWriter, r *http.Request) {
@@ -39,9 +144,23 @@ func (h *ApplicationHandler) CreateApplication(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	createdApp, err := h.appService.Create(r.Context(), userID, newApp)
+	force := r.URL.Query().Get("force") == "true"
+
+	createdApp, err := h.appService.Create(r.Context(), userID, newApp, force)
 	if err != nil {
 		log.Println("[AppHandler.CreateApplication] Error:", err)
+		var duplicate *domain.DuplicateError
+		if errors.As(err, &duplicate) {
+			jsonutil.RespondWithJSON(w, http.StatusConflict, map[string]any{
+				"error":      err.Error(),
+				"duplicates": duplicate.IDs,
+			})
+			return
+		}
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
 		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not create application")
 		return
 	}
@@ -53,10 +172,14 @@ func (h *ApplicationHandler) GetApplicationByID(w http.ResponseWriter, r *http.R
 	userID := r.Context().Value("userID").(string)
 	appID := chi.URLParam(r, "id")
 
-	app, err := h.appService.GetByID(r.Context(), userID, appID)
+	app, err := h.appService.GetDetail(r.Context(), userID, appID)
 	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
 		log.Println("[AppHandler.GetApplicationByID] Error:", err)
-		jsonutil.RespondWithError(w, http.StatusNotFound, err.Error())
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not get application")
 		return
 	}
 	jsonutil.RespondWithJSON(w, http.StatusOK, app)
@@ -75,25 +198,132 @@ func (h *ApplicationHandler) UpdateApplication(w http.ResponseWriter, r *http.Re
 
 	updatedApp, err := h.appService.Update(r.Context(), userID, appID, updateData)
 	if err != nil {
-		jsonutil.RespondWithError(w, http.StatusNotFound, err.Error())
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[AppHandler.UpdateApplicationByID] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not update application")
 		return
 	}
 	jsonutil.RespondWithJSON(w, http.StatusOK, updatedApp)
 }
 
+// BulkUpdateApplications applies one operation to many applications at once. It responds
+// 200 when every item went through and 422 when nothing was changed because some failed;
+// either way the body lists the outcome for each ID.
+func (h *ApplicationHandler) BulkUpdateApplications(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req domain.BulkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	result, err := h.appService.Bulk(r.Context(), userID, req)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[AppHandler.BulkUpdateApplications] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not update applications")
+		return
+	}
+	if !result.Applied {
+		jsonutil.RespondWithJSON(w, http.StatusUnprocessableEntity, result)
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, result)
+}
+
+// maxImportSize bounds the request body of an import, CSV included.
+const maxImportSize = 5 << 20
+
+// ImportApplications imports applications from CSV. A dry run responds 200 and a completed
+// import 201. When some row is invalid nothing is imported and it responds 422. Either way
+// the body reports the outcome of each row.
+func (h *ApplicationHandler) ImportApplications(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req domain.ImportRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxImportSize)).Decode(&req); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	result, err := h.appService.Import(r.Context(), userID, req)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[AppHandler.ImportApplications] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not import applications")
+		return
+	}
+
+	switch {
+	case result.Imported:
+		jsonutil.RespondWithJSON(w, http.StatusCreated, result)
+	case result.DryRun:
+		jsonutil.RespondWithJSON(w, http.StatusOK, result)
+	default:
+		jsonutil.RespondWithJSON(w, http.StatusUnprocessableEntity, result)
+	}
+}
+
+// ArchiveApplication soft-deletes an application. With ?permanent=true the
+// application, its notes and its history are removed for good instead.
 func (h *ApplicationHandler) ArchiveApplication(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(string)
 	appID := chi.URLParam(r, "id")
 
+	if r.URL.Query().Get("permanent") == "true" {
+		if err := h.appService.Delete(r.Context(), userID, appID); err != nil {
+			if status := statusFor(err, 0); status != 0 {
+				jsonutil.RespondWithError(w, status, err.Error())
+				return
+			}
+			log.Println("[AppHandler.DeleteApplication] Error:", err)
+			jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not delete application")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
 	err := h.appService.Archive(r.Context(), userID, appID)
 	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
 		log.Println("[AppHandler.ArchieveApplicationByID] Error:", err)
-		jsonutil.RespondWithError(w, http.StatusNotFound, err.Error())
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not archive application")
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *ApplicationHandler) RestoreApplication(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+
+	app, err := h.appService.Restore(r.Context(), userID, appID)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[AppHandler.RestoreApplication] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not restore application")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, app)
+}
+
 func (h *ApplicationHandler) AddNote(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(string)
 	appID := chi.URLParam(r, "id")
@@ -109,7 +339,12 @@ func (h *ApplicationHandler) AddNote(w http.ResponseWriter, r *http.Request) {
 
 	note, err := h.appService.AddNote(r.Context(), userID, appID, noteContent.Content)
 	if err != nil {
-		jsonutil.RespondWithError(w, http.StatusNotFound, err.Error())
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[AppHandler.AddNote] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not add note")
 		return
 	}
 	jsonutil.RespondWithJSON(w, http.StatusCreated, note)
@@ -131,7 +366,12 @@ func (h *ApplicationHandler) UpdateNote(w http.ResponseWriter, r *http.Request)
 
 	note, err := h.appService.UpdateNote(r.Context(), userID, appID, noteID, noteContent.Content)
 	if err != nil {
-		jsonutil.RespondWithError(w, http.StatusNotFound, err.Error())
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[AppHandler.UpdateNote] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not update note")
 		return
 	}
 	jsonutil.RespondWithJSON(w, http.StatusOK, note)
@@ -143,8 +383,12 @@ func (h *ApplicationHandler) DeleteNote(w http.ResponseWriter, r *http.Request)
 	noteID := chi.URLParam(r, "noteId")
 
 	if err := h.appService.DeleteNote(r.Context(), userID, appID, noteID); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
 		log.Println("[AppHandler.DeleteNote] Error:", err)
-		jsonutil.RespondWithError(w, http.StatusNotFound, err.Error())
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not delete note")
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -2,12 +2,18 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 
+	"joblog/internal/api/middleware"
 	"joblog/internal/core/domain"
 	"joblog/internal/core/service"
 	"joblog/pkg/jsonutil"
+
+	"github.com/go-chi/chi/v5"
 )
 
 type AuthHandler struct {
@@ -42,9 +48,31 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authResponse, err := h.authService.Login(r.Context(), loginDetails)
+	authResponse, err := h.authService.Login(r.Context(), loginDetails, sessionClient(r))
 	if err != nil {
 		log.Println("[AuthH.Login] Error:", err)
+		var limited *domain.RateLimitError
+		if errors.As(err, &limited) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(limited.RetryAfter.Seconds()))))
+		}
+		jsonutil.RespondWithError(w, statusFor(err, http.StatusUnauthorized), err.Error())
+		return
+	}
+
+	jsonutil.RespondWithJSON(w, http.StatusOK, authResponse)
+}
+
+// Refresh trades a refresh token for a new access token and refresh token.
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req domain.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	authResponse, err := h.authService.Refresh(r.Context(), req.RefreshToken, sessionClient(r))
+	if err != nil {
+		log.Println("[AuthH.Refresh] Error:", err)
 		jsonutil.RespondWithError(w, http.StatusUnauthorized, err.Error())
 		return
 	}
@@ -52,6 +80,128 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	jsonutil.RespondWithJSON(w, http.StatusOK, authResponse)
 }
 
+// VerifyEmail confirms the user's email address with the token from the verification email.
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req domain.VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.authService.VerifyEmail(r.Context(), req.Token); err != nil {
+		log.Println("[AuthH.VerifyEmail] Error:", err)
+		jsonutil.RespondWithError(w, statusFor(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResendVerificationEmail sends the logged-in user a new verification email.
+func (h *AuthHandler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.authService.ResendVerificationEmail(r.Context(), userID); err != nil {
+		log.Println("[AuthH.ResendVerificationEmail] Error:", err)
+		jsonutil.RespondWithError(w, statusFor(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ForgotPassword emails a password reset link. It answers the same whether or not an
+// account with the email exists.
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req domain.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.authService.ForgotPassword(r.Context(), req.Email); err != nil {
+		log.Println("[AuthH.ForgotPassword] Error:", err)
+		jsonutil.RespondWithError(w, statusFor(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	jsonutil.RespondWithJSON(w, http.StatusAccepted, map[string]string{
+		"message": "If an account with that email exists, a password reset link has been sent to it",
+	})
+}
+
+// ResetPassword sets a new password with the token from a reset email.
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req domain.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.authService.ResetPassword(r.Context(), req.Token, req.Password); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[AuthH.ResetPassword] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not reset password")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Logout revokes the session the request was authenticated with.
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	sessionID := r.Context().Value("sessionID").(string)
+
+	if err := h.authService.Logout(r.Context(), userID, sessionID); err != nil {
+		log.Println("[AuthH.Logout] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not log out")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSessions lists the devices the user is logged in on.
+func (h *AuthHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	sessionID := r.Context().Value("sessionID").(string)
+
+	sessions, err := h.authService.ListSessions(r.Context(), userID, sessionID)
+	if err != nil {
+		log.Println("[AuthH.GetSessions] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not retrieve sessions")
+		return
+	}
+
+	jsonutil.RespondWithJSON(w, http.StatusOK, sessions)
+}
+
+// RevokeSession logs one of the user's devices out.
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := h.authService.RevokeSession(r.Context(), userID, chi.URLParam(r, "sessionId")); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[AuthH.RevokeSession] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not revoke session")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sessionClient describes the device a login or refresh comes from, for the session list.
+func sessionClient(r *http.Request) domain.SessionClient {
+	return domain.SessionClient{UserAgent: r.UserAgent(), IP: middleware.ClientIP(r)}
+}
+
 func (h *AuthHandler) GetMyProfile(w http.ResponseWriter, r *http.Request) {
 	userID, ok := r.Context().Value("userID").(string)
 	if !ok {
@@ -68,3 +218,64 @@ func (h *AuthHandler) GetMyProfile(w http.ResponseWriter, r *http.Request) {
 
 	jsonutil.RespondWithJSON(w, http.StatusOK, user)
 }
+
+// UpdateMyProfile changes the logged-in user's username and/or email.
+func (h *AuthHandler) UpdateMyProfile(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req domain.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	user, err := h.authService.UpdateProfile(r.Context(), userID, req)
+	if err != nil {
+		log.Println("[AuthH.UpdateProfile] Error:", err)
+		jsonutil.RespondWithError(w, statusFor(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	jsonutil.RespondWithJSON(w, http.StatusOK, user)
+}
+
+// ChangeMyPassword sets a new password for the logged-in user, given the current one.
+func (h *AuthHandler) ChangeMyPassword(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	sessionID := r.Context().Value("sessionID").(string)
+
+	var req domain.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.authService.ChangePassword(r.Context(), userID, sessionID, req); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[AuthH.ChangePassword] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not change password")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteMyAccount permanently deletes the logged-in user and everything they own.
+func (h *AuthHandler) DeleteMyAccount(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(string)
+	if !ok {
+		jsonutil.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.authService.DeleteAccount(r.Context(), userID); err != nil {
+		log.Println("[AuthH.DeleteAccount] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not delete account")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
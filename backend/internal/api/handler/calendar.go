@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"joblog/internal/core/service"
+	"joblog/pkg/jsonutil"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CalendarHandler struct {
+	calendarService *service.CalendarService
+}
+
+func NewCalendarHandler(calendarService *service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+// CreateFeedToken issues (or rotates) the user's calendar feed token.
+// The returned URL can be pasted into any calendar app that subscribes to iCalendar feeds.
+func (h *CalendarHandler) CreateFeedToken(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	token, err := h.calendarService.CreateToken(r.Context(), userID)
+	if err != nil {
+		log.Println("[CalendarH.CreateFeedToken] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not create calendar token")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusCreated, map[string]string{
+		"token": token,
+		"url":   "/api/calendar/" + token + ".ics",
+	})
+}
+
+func (h *CalendarHandler) RevokeFeedToken(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	if err := h.calendarService.RevokeToken(r.Context(), userID); err != nil {
+		log.Println("[CalendarH.RevokeFeedToken] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not revoke calendar token")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetFeed serves the iCalendar feed. It is authenticated by the token in the URL.
+func (h *CalendarHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	calendar, err := h.calendarService.Feed(r.Context(), token)
+	if err != nil {
+		log.Println("[CalendarH.GetFeed] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusNotFound, "Calendar not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="joblog.ics"`)
+	if err := calendar.Encode(w); err != nil {
+		log.Println("[CalendarH.GetFeed] Error writing feed:", err)
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"joblog/internal/core/domain"
+	"joblog/internal/core/service"
+	"joblog/pkg/jsonutil"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CompanyHandler struct {
+	companyService *service.CompanyService
+}
+
+func NewCompanyHandler(companyService *service.CompanyService) *CompanyHandler {
+	return &CompanyHandler{companyService: companyService}
+}
+
+func (h *CompanyHandler) GetCompanies(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	companies, err := h.companyService.List(r.Context(), userID)
+	if err != nil {
+		log.Println("[CompanyH.GetCompanies] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not retrieve companies")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, companies)
+}
+
+func (h *CompanyHandler) GetCompany(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	companyID := chi.URLParam(r, "companyId")
+
+	company, err := h.companyService.GetDetail(r.Context(), userID, companyID)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[CompanyH.GetCompany] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not get company")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, company)
+}
+
+func (h *CompanyHandler) UpdateCompany(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	companyID := chi.URLParam(r, "companyId")
+
+	var updateData domain.CompanyUpdate
+	if err := json.NewDecoder(r.Body).Decode(&updateData); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	company, err := h.companyService.Update(r.Context(), userID, companyID, updateData)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[CompanyH.UpdateCompany] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not update company")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, company)
+}
+
+// DeleteCompany removes a company without applications; one that still has some is a 409.
+func (h *CompanyHandler) DeleteCompany(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	companyID := chi.URLParam(r, "companyId")
+
+	if err := h.companyService.Delete(r.Context(), userID, companyID); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[CompanyH.DeleteCompany] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not delete company")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"joblog/internal/core/domain"
+	"joblog/internal/core/service"
+	"joblog/pkg/jsonutil"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ContactHandler struct {
+	contactService *service.ContactService
+}
+
+func NewContactHandler(contactService *service.ContactService) *ContactHandler {
+	return &ContactHandler{contactService: contactService}
+}
+
+// GetContacts lists the user's contacts; ?q= searches name, email, company and title.
+func (h *ContactHandler) GetContacts(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	contacts, err := h.contactService.List(r.Context(), userID, r.URL.Query().Get("q"))
+	if err != nil {
+		log.Println("[ContactH.GetContacts] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not retrieve contacts")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, contacts)
+}
+
+func (h *ContactHandler) CreateContact(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var newContact domain.NewContact
+	if err := json.NewDecoder(r.Body).Decode(&newContact); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	contact, err := h.contactService.Create(r.Context(), userID, newContact)
+	if err != nil {
+		log.Println("[ContactH.CreateContact] Error:", err)
+		jsonutil.RespondWithError(w, statusFor(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusCreated, contact)
+}
+
+func (h *ContactHandler) GetContact(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	contactID := chi.URLParam(r, "contactId")
+
+	contact, err := h.contactService.GetByID(r.Context(), userID, contactID)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[ContactH.GetContact] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not get contact")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, contact)
+}
+
+func (h *ContactHandler) UpdateContact(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	contactID := chi.URLParam(r, "contactId")
+
+	var updateData domain.ContactUpdate
+	if err := json.NewDecoder(r.Body).Decode(&updateData); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	contact, err := h.contactService.Update(r.Context(), userID, contactID, updateData)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[ContactH.UpdateContact] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not update contact")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, contact)
+}
+
+func (h *ContactHandler) DeleteContact(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	contactID := chi.URLParam(r, "contactId")
+
+	if err := h.contactService.Delete(r.Context(), userID, contactID); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[ContactH.DeleteContact] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not delete contact")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// |--- Application links ---
+
+func (h *ContactHandler) GetApplicationContacts(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+
+	contacts, err := h.contactService.ListForApplication(r.Context(), userID, appID)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[ContactH.GetApplicationContacts] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not retrieve contacts")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, contacts)
+}
+
+// LinkContact attaches a contact to the application, or changes its role if already linked.
+func (h *ContactHandler) LinkContact(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+	contactID := chi.URLParam(r, "contactId")
+
+	var payload struct {
+		Role domain.ContactRole `json:"role"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	contacts, err := h.contactService.Link(r.Context(), userID, appID, contactID, payload.Role)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[ContactH.LinkContact] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not link contact")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, contacts)
+}
+
+func (h *ContactHandler) UnlinkContact(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+	contactID := chi.URLParam(r, "contactId")
+
+	if err := h.contactService.Unlink(r.Context(), userID, appID, contactID); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[ContactH.UnlinkContact] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not unlink contact")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
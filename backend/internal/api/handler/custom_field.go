@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"joblog/internal/core/domain"
+	"joblog/internal/core/service"
+	"joblog/pkg/jsonutil"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CustomFieldHandler struct {
+	customFieldService *service.CustomFieldService
+}
+
+func NewCustomFieldHandler(customFieldService *service.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{customFieldService: customFieldService}
+}
+
+func (h *CustomFieldHandler) GetCustomFields(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	fields, err := h.customFieldService.List(r.Context(), userID)
+	if err != nil {
+		log.Println("[CustomFieldH.GetCustomFields] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not retrieve custom fields")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, fields)
+}
+
+func (h *CustomFieldHandler) CreateCustomField(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var newField domain.NewCustomField
+	if err := json.NewDecoder(r.Body).Decode(&newField); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	field, err := h.customFieldService.Create(r.Context(), userID, newField)
+	if err != nil {
+		log.Println("[CustomFieldH.CreateCustomField] Error:", err)
+		jsonutil.RespondWithError(w, statusFor(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusCreated, field)
+}
+
+func (h *CustomFieldHandler) UpdateCustomField(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	fieldID := chi.URLParam(r, "fieldId")
+
+	var updateData domain.CustomFieldUpdate
+	if err := json.NewDecoder(r.Body).Decode(&updateData); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	field, err := h.customFieldService.Update(r.Context(), userID, fieldID, updateData)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[CustomFieldH.UpdateCustomField] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not update custom field")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, field)
+}
+
+func (h *CustomFieldHandler) DeleteCustomField(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	fieldID := chi.URLParam(r, "fieldId")
+
+	if err := h.customFieldService.Delete(r.Context(), userID, fieldID); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[CustomFieldH.DeleteCustomField] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not delete custom field")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"joblog/internal/core/domain"
+)
+
+// statusFor maps the domain's sentinel errors to HTTP status codes,
+// falling back to the given status for anything else.
+func statusFor(err error, fallback int) int {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidTransition):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrRateLimited):
+		return http.StatusTooManyRequests
+	}
+	return fallback
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"joblog/internal/core/domain"
+	"joblog/pkg/jsonutil"
+	"joblog/pkg/xlsx"
+)
+
+// maxBackupSize bounds the request body of a backup restore.
+const maxBackupSize = 50 << 20
+
+// exportWriter records whether any of the export has been sent, after which an error can
+// no longer be reported with a status code.
+type exportWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (w *exportWriter) Write(b []byte) (int, error) {
+	w.started = true
+	return w.ResponseWriter.Write(b)
+}
+
+// ExportApplications handles GET /applications/export?format=csv|json|xlsx, streaming every
+// application that matches the list endpoint's filters. JSON is a full backup that
+// RestoreBackup accepts; CSV and XLSX are flat sheets for spreadsheet programs.
+func (h *ApplicationHandler) ExportApplications(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	filter, err := parseApplicationFilter(r)
+	if err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	out := &exportWriter{ResponseWriter: w}
+	switch format {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		err = h.writeCSV(out, r, userID, filter)
+	case "xlsx":
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		err = h.writeXLSX(out, r, userID, filter)
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		err = h.writeBackup(out, r, userID, filter)
+	default:
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "format must be csv, json or xlsx")
+		return
+	}
+	if err == nil {
+		return
+	}
+
+	log.Println("[AppHandler.ExportApplications] Error:", err)
+	if !out.started {
+		w.Header().Del("Content-Disposition")
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+		} else {
+			jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not export applications")
+		}
+	}
+	// Otherwise the response is cut short, which clients see as a failed download
+}
+
+func setAttachment(w http.ResponseWriter, extension string) {
+	filename := "joblog-" + time.Now().Format("2006-01-02") + "." + extension
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+}
+
+func (h *ApplicationHandler) writeCSV(w *exportWriter, r *http.Request, userID string, filter domain.ApplicationFilter) error {
+	setAttachment(w, "csv")
+	cw := csv.NewWriter(w)
+	err := h.appService.ExportTable(r.Context(), userID, filter, func(row []string) error {
+		for i, cell := range row {
+			row[i] = csvSafe(cell)
+		}
+		return cw.Write(row)
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvSafe quotes a cell that spreadsheet programs would otherwise run as a formula, since
+// company names, notes and the like are free text.
+func csvSafe(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+func (h *ApplicationHandler) writeXLSX(w *exportWriter, r *http.Request, userID string, filter domain.ApplicationFilter) error {
+	setAttachment(w, "xlsx")
+	var xw *xlsx.Writer
+	err := h.appService.ExportTable(r.Context(), userID, filter, func(row []string) error {
+		if xw == nil {
+			var err error
+			if xw, err = xlsx.NewWriter(w, "Applications"); err != nil {
+				return err
+			}
+		}
+		return xw.WriteRow(row)
+	})
+	if err != nil {
+		return err
+	}
+	return xw.Close()
+}
+
+// writeBackup streams a domain.Backup, writing the applications one at a time.
+func (h *ApplicationHandler) writeBackup(w *exportWriter, r *http.Request, userID string, filter domain.ApplicationFilter) error {
+	header, err := h.appService.BackupHeader(r.Context(), userID)
+	if err != nil {
+		return err
+	}
+	tags, err := json.Marshal(header.Tags)
+	if err != nil {
+		return err
+	}
+	fields, err := json.Marshal(header.CustomFields)
+	if err != nil {
+		return err
+	}
+	exportedAt, err := json.Marshal(header.ExportedAt)
+	if err != nil {
+		return err
+	}
+
+	setAttachment(w, "json")
+	count := 0
+	start := func() error {
+		_, err := fmt.Fprintf(w, `{"version":%d,"exportedAt":%s,"tags":%s,"customFields":%s,"applications":[`,
+			header.Version, exportedAt, tags, fields)
+		return err
+	}
+	err = h.appService.Export(r.Context(), userID, filter, func(app *domain.Application) error {
+		b, err := json.Marshal(app)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			err = start()
+		} else {
+			_, err = io.WriteString(w, ",")
+		}
+		if err != nil {
+			return err
+		}
+		count++
+		_, err = w.Write(b)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		if err := start(); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "]}\n")
+	return err
+}
+
+// RestoreBackup handles POST /applications/import/backup with a JSON export as the body.
+func (h *ApplicationHandler) RestoreBackup(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var backup domain.Backup
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBackupSize)).Decode(&backup); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	result, err := h.appService.RestoreBackup(r.Context(), userID, &backup)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[AppHandler.RestoreBackup] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not restore backup")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusCreated, result)
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"joblog/internal/core/domain"
+	"joblog/internal/core/service"
+	"joblog/pkg/jsonutil"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type InterviewHandler struct {
+	interviewService *service.InterviewService
+}
+
+func NewInterviewHandler(interviewService *service.InterviewService) *InterviewHandler {
+	return &InterviewHandler{interviewService: interviewService}
+}
+
+func (h *InterviewHandler) GetInterviews(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+
+	interviews, err := h.interviewService.ListByApplication(r.Context(), userID, appID)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[InterviewH.GetInterviews] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not retrieve interviews")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, interviews)
+}
+
+func (h *InterviewHandler) ScheduleInterview(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+
+	var newInterview domain.NewInterview
+	if err := json.NewDecoder(r.Body).Decode(&newInterview); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	interview, err := h.interviewService.Schedule(r.Context(), userID, appID, newInterview)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[InterviewH.ScheduleInterview] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not schedule interview")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusCreated, interview)
+}
+
+func (h *InterviewHandler) GetInterview(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+	interviewID := chi.URLParam(r, "interviewId")
+
+	interview, err := h.interviewService.GetByID(r.Context(), userID, appID, interviewID)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[InterviewH.GetInterview] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not get interview")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, interview)
+}
+
+func (h *InterviewHandler) UpdateInterview(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+	interviewID := chi.URLParam(r, "interviewId")
+
+	var updateData domain.InterviewUpdate
+	if err := json.NewDecoder(r.Body).Decode(&updateData); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	interview, err := h.interviewService.Update(r.Context(), userID, appID, interviewID, updateData)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[InterviewH.UpdateInterview] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not update interview")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, interview)
+}
+
+func (h *InterviewHandler) DeleteInterview(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+	interviewID := chi.URLParam(r, "interviewId")
+
+	if err := h.interviewService.Delete(r.Context(), userID, appID, interviewID); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[InterviewH.DeleteInterview] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not delete interview")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
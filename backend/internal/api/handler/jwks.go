@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"net/http"
+
+	"joblog/pkg/auth"
+	"joblog/pkg/jsonutil"
+)
+
+type JWKSHandler struct {
+	jwtManager *auth.JWTManager
+}
+
+func NewJWKSHandler(jwtManager *auth.JWTManager) *JWKSHandler {
+	return &JWKSHandler{jwtManager: jwtManager}
+}
+
+// GetJWKS publishes the public keys access tokens are signed with. The cache lifetime is
+// kept short so a key added during rotation is picked up before tokens are signed with it.
+func (h *JWKSHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	jsonutil.RespondWithJSON(w, http.StatusOK, h.jwtManager.JWKS())
+}
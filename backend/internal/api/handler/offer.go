@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"joblog/internal/core/domain"
+	"joblog/internal/core/service"
+	"joblog/pkg/jsonutil"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type OfferHandler struct {
+	offerService *service.OfferService
+}
+
+func NewOfferHandler(offerService *service.OfferService) *OfferHandler {
+	return &OfferHandler{offerService: offerService}
+}
+
+func (h *OfferHandler) GetOffers(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	offers, err := h.offerService.List(r.Context(), userID)
+	if err != nil {
+		log.Println("[OfferH.GetOffers] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not retrieve offers")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, offers)
+}
+
+// CompareOffers handles GET /offers/compare?ids=a,b,c
+func (h *OfferHandler) CompareOffers(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var ids []string
+	for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	comparison, err := h.offerService.Compare(r.Context(), userID, ids)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[OfferH.CompareOffers] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not compare offers")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, comparison)
+}
+
+// GetOfferDeadlines handles GET /offers/deadlines?days=7, listing pending offers due within that many days.
+func (h *OfferHandler) GetOfferDeadlines(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	days := 7
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 365 {
+			jsonutil.RespondWithError(w, http.StatusBadRequest, "days must be an integer between 1 and 365")
+			return
+		}
+		days = n
+	}
+
+	deadlines, err := h.offerService.Deadlines(r.Context(), userID, time.Duration(days)*24*time.Hour)
+	if err != nil {
+		log.Println("[OfferH.GetOfferDeadlines] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not retrieve offer deadlines")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, deadlines)
+}
+
+func (h *OfferHandler) GetApplicationOffer(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+
+	offer, err := h.offerService.GetForApplication(r.Context(), userID, appID)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[OfferH.GetApplicationOffer] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not get offer")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, offer)
+}
+
+func (h *OfferHandler) CreateOffer(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+
+	var newOffer domain.NewOffer
+	if err := json.NewDecoder(r.Body).Decode(&newOffer); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	offer, err := h.offerService.Create(r.Context(), userID, appID, newOffer)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[OfferH.CreateOffer] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not create offer")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusCreated, offer)
+}
+
+func (h *OfferHandler) UpdateOffer(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+
+	var updateData domain.OfferUpdate
+	if err := json.NewDecoder(r.Body).Decode(&updateData); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	offer, err := h.offerService.Update(r.Context(), userID, appID, updateData)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[OfferH.UpdateOffer] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not update offer")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, offer)
+}
+
+func (h *OfferHandler) AddNegotiation(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+
+	var entry domain.NewNegotiationEntry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	offer, err := h.offerService.AddNegotiation(r.Context(), userID, appID, entry)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[OfferH.AddNegotiation] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not add negotiation")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusCreated, offer)
+}
+
+func (h *OfferHandler) DeleteOffer(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+
+	if err := h.offerService.Delete(r.Context(), userID, appID); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[OfferH.DeleteOffer] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not delete offer")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"joblog/internal/core/domain"
+	"joblog/internal/core/service"
+	"joblog/pkg/jsonutil"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ReminderHandler struct {
+	reminderService *service.ReminderService
+}
+
+func NewReminderHandler(reminderService *service.ReminderService) *ReminderHandler {
+	return &ReminderHandler{reminderService: reminderService}
+}
+
+func (h *ReminderHandler) GetReminders(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	reminders, err := h.reminderService.List(r.Context(), userID)
+	if err != nil {
+		log.Println("[ReminderH.GetReminders] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not retrieve reminders")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, reminders)
+}
+
+func (h *ReminderHandler) GetDueReminders(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	reminders, err := h.reminderService.ListDue(r.Context(), userID)
+	if err != nil {
+		log.Println("[ReminderH.GetDueReminders] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not retrieve reminders")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, reminders)
+}
+
+func (h *ReminderHandler) GetApplicationReminders(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+
+	reminders, err := h.reminderService.ListByApplication(r.Context(), userID, appID)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[ReminderH.GetApplicationReminders] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not retrieve reminders")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, reminders)
+}
+
+func (h *ReminderHandler) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+
+	var newReminder domain.NewReminder
+	if err := json.NewDecoder(r.Body).Decode(&newReminder); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	reminder, err := h.reminderService.Create(r.Context(), userID, appID, newReminder)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[ReminderH.CreateReminder] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not create reminder")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusCreated, reminder)
+}
+
+func (h *ReminderHandler) UpdateReminder(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	reminderID := chi.URLParam(r, "reminderId")
+
+	var updateData domain.ReminderUpdate
+	if err := json.NewDecoder(r.Body).Decode(&updateData); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	reminder, err := h.reminderService.Update(r.Context(), userID, reminderID, updateData)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[ReminderH.UpdateReminder] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not update reminder")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, reminder)
+}
+
+func (h *ReminderHandler) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	reminderID := chi.URLParam(r, "reminderId")
+
+	if err := h.reminderService.Delete(r.Context(), userID, reminderID); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[ReminderH.DeleteReminder] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not delete reminder")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// |--- Follow-up rules ---
+
+func (h *ReminderHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	rules, err := h.reminderService.ListRules(r.Context(), userID)
+	if err != nil {
+		log.Println("[ReminderH.GetRules] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not retrieve follow-up rules")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, rules)
+}
+
+func (h *ReminderHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var newRule domain.NewFollowUpRule
+	if err := json.NewDecoder(r.Body).Decode(&newRule); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	rule, err := h.reminderService.CreateRule(r.Context(), userID, newRule)
+	if err != nil {
+		log.Println("[ReminderH.CreateRule] Error:", err)
+		jsonutil.RespondWithError(w, statusFor(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusCreated, rule)
+}
+
+func (h *ReminderHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	ruleID := chi.URLParam(r, "ruleId")
+
+	var updateData domain.FollowUpRuleUpdate
+	if err := json.NewDecoder(r.Body).Decode(&updateData); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	rule, err := h.reminderService.UpdateRule(r.Context(), userID, ruleID, updateData)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[ReminderH.UpdateRule] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not update follow-up rule")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, rule)
+}
+
+func (h *ReminderHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	ruleID := chi.URLParam(r, "ruleId")
+
+	if err := h.reminderService.DeleteRule(r.Context(), userID, ruleID); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[ReminderH.DeleteRule] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not delete follow-up rule")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"joblog/internal/core/service"
+	"joblog/pkg/jsonutil"
+)
+
+type SearchHandler struct {
+	searchService *service.SearchService
+}
+
+func NewSearchHandler(searchService *service.SearchService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+// Search handles GET /search?q=...&limit=20, returning matching applications best first
+// with highlighted snippets of where they matched.
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			jsonutil.RespondWithError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = n
+	}
+
+	results, err := h.searchService.Search(r.Context(), userID, r.URL.Query().Get("q"), limit)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[SearchH.Search] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not search applications")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, results)
+}
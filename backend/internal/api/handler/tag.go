@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"joblog/internal/core/domain"
+	"joblog/internal/core/service"
+	"joblog/pkg/jsonutil"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type TagHandler struct {
+	tagService *service.TagService
+}
+
+func NewTagHandler(tagService *service.TagService) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+func (h *TagHandler) GetTags(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	tags, err := h.tagService.List(r.Context(), userID)
+	if err != nil {
+		log.Println("[TagH.GetTags] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not retrieve tags")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, tags)
+}
+
+func (h *TagHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var newTag domain.NewTag
+	if err := json.NewDecoder(r.Body).Decode(&newTag); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	tag, err := h.tagService.Create(r.Context(), userID, newTag)
+	if err != nil {
+		log.Println("[TagH.CreateTag] Error:", err)
+		jsonutil.RespondWithError(w, statusFor(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusCreated, tag)
+}
+
+func (h *TagHandler) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	tagID := chi.URLParam(r, "tagId")
+
+	var updateData domain.TagUpdate
+	if err := json.NewDecoder(r.Body).Decode(&updateData); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	tag, err := h.tagService.Update(r.Context(), userID, tagID, updateData)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[TagH.UpdateTag] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not update tag")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, tag)
+}
+
+func (h *TagHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	tagID := chi.URLParam(r, "tagId")
+
+	if err := h.tagService.Delete(r.Context(), userID, tagID); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[TagH.DeleteTag] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not delete tag")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MergeTag merges the tag in the URL into the one named by {"into": "<tag id>"}.
+func (h *TagHandler) MergeTag(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	tagID := chi.URLParam(r, "tagId")
+
+	var payload struct {
+		Into string `json:"into"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	tag, err := h.tagService.Merge(r.Context(), userID, tagID, payload.Into)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[TagH.MergeTag] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not merge tags")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, tag)
+}
+
+func (h *TagHandler) AssignTag(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+	tagID := chi.URLParam(r, "tagId")
+
+	tags, err := h.tagService.Assign(r.Context(), userID, appID, tagID)
+	if err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[TagH.AssignTag] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not assign tag")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, tags)
+}
+
+func (h *TagHandler) UnassignTag(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	appID := chi.URLParam(r, "id")
+	tagID := chi.URLParam(r, "tagId")
+
+	if err := h.tagService.Unassign(r.Context(), userID, appID, tagID); err != nil {
+		if status := statusFor(err, 0); status != 0 {
+			jsonutil.RespondWithError(w, status, err.Error())
+			return
+		}
+		log.Println("[TagH.UnassignTag] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not unassign tag")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
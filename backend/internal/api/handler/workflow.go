@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"joblog/internal/core/domain"
+	"joblog/internal/core/service"
+	"joblog/pkg/jsonutil"
+)
+
+type WorkflowHandler struct {
+	workflowService *service.WorkflowService
+}
+
+func NewWorkflowHandler(workflowService *service.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{workflowService: workflowService}
+}
+
+func (h *WorkflowHandler) GetWorkflow(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	workflow, err := h.workflowService.Get(r.Context(), userID)
+	if err != nil {
+		log.Println("[WorkflowH.GetWorkflow] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not fetch workflow")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, workflow)
+}
+
+func (h *WorkflowHandler) UpdateWorkflow(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	var workflow domain.Workflow
+	if err := json.NewDecoder(r.Body).Decode(&workflow); err != nil {
+		jsonutil.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	updated, err := h.workflowService.Update(r.Context(), userID, &workflow)
+	if err != nil {
+		log.Println("[WorkflowH.UpdateWorkflow] Error:", err)
+		jsonutil.RespondWithError(w, statusFor(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, updated)
+}
+
+// ResetWorkflow goes back to the default workflow.
+func (h *WorkflowHandler) ResetWorkflow(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	workflow, err := h.workflowService.Reset(r.Context(), userID)
+	if err != nil {
+		log.Println("[WorkflowH.ResetWorkflow] Error:", err)
+		jsonutil.RespondWithError(w, http.StatusInternalServerError, "Could not reset workflow")
+		return
+	}
+	jsonutil.RespondWithJSON(w, http.StatusOK, workflow)
+}
@@ -10,7 +10,13 @@ import (
 	"joblog/pkg/jsonutil"
 )
 
-func Authenticator(jwtManager *auth.JWTManager) func(http.Handler) http.Handler {
+// SessionChecker reports whether a login session is still active, so access tokens of
+// revoked sessions are rejected before they expire.
+type SessionChecker interface {
+	SessionActive(ctx context.Context, userID, sessionID string) (bool, error)
+}
+
+func Authenticator(jwtManager *auth.JWTManager, sessions SessionChecker) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -32,8 +38,15 @@ func Authenticator(jwtManager *auth.JWTManager) func(http.Handler) http.Handler
 				return
 			}
 
-			// Add user ID to context for downstream handlers
+			active, err := sessions.SessionActive(r.Context(), claims.UserID, claims.SessionID)
+			if err != nil || !active {
+				jsonutil.RespondWithError(w, http.StatusUnauthorized, "Session has expired or been revoked")
+				return
+			}
+
+			// Add user and session IDs to context for downstream handlers
 			ctx := context.WithValue(r.Context(), "userID", claims.UserID)
+			ctx = context.WithValue(ctx, "sessionID", claims.SessionID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
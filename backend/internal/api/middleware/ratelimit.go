@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"joblog/pkg/jsonutil"
+)
+
+// Limiter counts a hit for a key and reports whether it is allowed, and if not, how long
+// until it would be.
+type Limiter interface {
+	Allow(ctx context.Context, key string) (bool, time.Duration, error)
+}
+
+// RateLimit refuses requests with 429 Too Many Requests once the limiter's limit for the
+// request's key is reached. Requests key returns no key for are not limited. If the
+// limiter fails, requests are let through rather than locking everyone out.
+func RateLimit(limiter Limiter, key func(r *http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			k := key(r)
+			if k == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			allowed, retryAfter, err := limiter.Allow(r.Context(), k)
+			if err != nil {
+				log.Println("[RateLimit] Error:", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				jsonutil.RespondWithError(w, http.StatusTooManyRequests, "Too many attempts; try again later")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// TrustedProxies are the addresses of reverse proxies and load balancers in front of the
+// server, whose X-Forwarded-For headers are believed.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies reads a comma-separated list of IP addresses and CIDR ranges.
+func ParseTrustedProxies(list string) (TrustedProxies, error) {
+	var proxies TrustedProxies
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR range %q", entry)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+func (p TrustedProxies) contains(ip net.IP) bool {
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// RealIP works out the client's IP address and stores it in the request context for
+// ClientIP. Requests from a trusted proxy are attributed to the last address in their
+// X-Forwarded-For header that isn't a trusted proxy itself; from anywhere else, the
+// header is ignored, since clients could set it to get around per-IP limits.
+func RealIP(trusted TrustedProxies) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), "clientIP", trusted.clientIP(r))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func (p TrustedProxies) clientIP(r *http.Request) string {
+	client, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		client = r.RemoteAddr
+	}
+	if ip := net.ParseIP(client); ip == nil || !p.contains(ip) {
+		return client
+	}
+
+	// Each proxy appends the address it got the request from, so walk back from the end
+	var forwarded []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(header, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if ip == nil {
+			break // Whatever comes before a malformed entry can't be trusted either
+		}
+		client = ip.String()
+		if !p.contains(ip) {
+			break
+		}
+	}
+	return client
+}
+
+// ClientIP returns the IP address the request came from, as worked out by RealIP.
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value("clientIP").(string); ok {
+		return ip
+	}
+	return TrustedProxies(nil).clientIP(r)
+}
+
+// LoginUsername returns the username of a login request, leaving the body for the handler
+// to read again.
+func LoginUsername(r *http.Request) string {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 64<<10))
+	if err != nil {
+		return ""
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var login struct {
+		Username string `json:"username"`
+	}
+	if err := json.Unmarshal(body, &login); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(login.Username)
+}
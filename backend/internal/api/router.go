@@ -19,12 +19,29 @@ func NewRouter(
 	authHandler *handler.AuthHandler,
 	appHandler *handler.ApplicationHandler,
 	blogHandler *handler.BlogHandler,
+	workflowHandler *handler.WorkflowHandler,
+	interviewHandler *handler.InterviewHandler,
+	calendarHandler *handler.CalendarHandler,
+	reminderHandler *handler.ReminderHandler,
+	contactHandler *handler.ContactHandler,
+	companyHandler *handler.CompanyHandler,
+	offerHandler *handler.OfferHandler,
+	tagHandler *handler.TagHandler,
+	customFieldHandler *handler.CustomFieldHandler,
+	searchHandler *handler.SearchHandler,
+	jwksHandler *handler.JWKSHandler,
 	jwtManager *auth.JWTManager,
+	sessions middleware.SessionChecker,
+	trustedProxies middleware.TrustedProxies,
+	loginIPLimiter middleware.Limiter,
+	loginUsernameLimiter middleware.Limiter,
+	forgotPasswordLimiter middleware.Limiter,
 ) http.Handler {
 	r := chi.NewRouter()
 
 	r.Use(chi_middleware.Logger)
 	r.Use(chi_middleware.Recoverer)
+	r.Use(middleware.RealIP(trustedProxies))
 	r.Use(chi_middleware.RequestID)
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
@@ -35,6 +52,8 @@ func NewRouter(
 		MaxAge:           300,
 	}))
 
+	r.Get("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	r.Route("/api", func(r chi.Router) {
 		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 			w.Write([]byte("Welcome to JobLog API"))
@@ -42,7 +61,15 @@ func NewRouter(
 
 		r.Route("/auth", func(r chi.Router) {
 			r.Post("/register", authHandler.Register)
-			r.Post("/login", authHandler.Login)
+			r.With(
+				middleware.RateLimit(loginIPLimiter, middleware.ClientIP),
+				middleware.RateLimit(loginUsernameLimiter, middleware.LoginUsername),
+			).Post("/login", authHandler.Login)
+			r.Post("/refresh", authHandler.Refresh)
+			r.Post("/verify-email", authHandler.VerifyEmail)
+			// Every request can send an email
+			r.With(middleware.RateLimit(forgotPasswordLimiter, middleware.ClientIP)).Post("/forgot-password", authHandler.ForgotPassword)
+			r.Post("/reset-password", authHandler.ResetPassword)
 		})
 
 		r.Route("/blog", func(r chi.Router) {
@@ -50,18 +77,34 @@ func NewRouter(
 			r.Get("/{slug}", blogHandler.GetBlogPostBySlug)
 		})
 
+		// Calendar clients can't send Authorization headers; the token in the URL authenticates the feed
+		r.Get("/calendar/{token}.ics", calendarHandler.GetFeed)
+
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.Authenticator(jwtManager))
+			r.Use(middleware.Authenticator(jwtManager, sessions))
 
 			r.Get("/auth/me", authHandler.GetMyProfile)
+			r.Put("/auth/me", authHandler.UpdateMyProfile)
+			r.Put("/auth/me/password", authHandler.ChangeMyPassword)
+			r.Delete("/auth/me", authHandler.DeleteMyAccount)
+			r.Post("/auth/logout", authHandler.Logout)
+			r.Post("/auth/verify-email/resend", authHandler.ResendVerificationEmail)
+			r.Get("/auth/sessions", authHandler.GetSessions)
+			r.Delete("/auth/sessions/{sessionId}", authHandler.RevokeSession)
 
 			r.Route("/applications", func(r chi.Router) {
 				r.Get("/", appHandler.GetAllApplications)
 				r.Post("/", appHandler.CreateApplication)
+				r.Get("/stats", appHandler.GetApplicationStats)
+				r.Post("/bulk", appHandler.BulkUpdateApplications)
+				r.Post("/import", appHandler.ImportApplications)
+				r.Post("/import/backup", appHandler.RestoreBackup)
+				r.Get("/export", appHandler.ExportApplications)
 				r.Route("/{id}", func(r chi.Router) {
 					r.Get("/", appHandler.GetApplicationByID)
 					r.Put("/", appHandler.UpdateApplication)
 					r.Delete("/", appHandler.ArchiveApplication)
+					r.Post("/restore", appHandler.RestoreApplication)
 
 					r.Route("/notes", func(r chi.Router) {
 						r.Post("/", appHandler.AddNote)
@@ -70,9 +113,117 @@ func NewRouter(
 							r.Delete("/", appHandler.DeleteNote)
 						})
 					})
+
+					r.Route("/interviews", func(r chi.Router) {
+						r.Get("/", interviewHandler.GetInterviews)
+						r.Post("/", interviewHandler.ScheduleInterview)
+						r.Route("/{interviewId}", func(r chi.Router) {
+							r.Get("/", interviewHandler.GetInterview)
+							r.Put("/", interviewHandler.UpdateInterview)
+							r.Delete("/", interviewHandler.DeleteInterview)
+						})
+					})
+
+					r.Route("/reminders", func(r chi.Router) {
+						r.Get("/", reminderHandler.GetApplicationReminders)
+						r.Post("/", reminderHandler.CreateReminder)
+					})
+
+					r.Route("/tags", func(r chi.Router) {
+						r.Put("/{tagId}", tagHandler.AssignTag)
+						r.Delete("/{tagId}", tagHandler.UnassignTag)
+					})
+
+					r.Route("/offer", func(r chi.Router) {
+						r.Get("/", offerHandler.GetApplicationOffer)
+						r.Post("/", offerHandler.CreateOffer)
+						r.Put("/", offerHandler.UpdateOffer)
+						r.Delete("/", offerHandler.DeleteOffer)
+						r.Post("/negotiations", offerHandler.AddNegotiation)
+					})
+
+					r.Route("/contacts", func(r chi.Router) {
+						r.Get("/", contactHandler.GetApplicationContacts)
+						r.Put("/{contactId}", contactHandler.LinkContact)
+						r.Delete("/{contactId}", contactHandler.UnlinkContact)
+					})
 				})
 			})
 
+			r.Route("/companies", func(r chi.Router) {
+				r.Get("/", companyHandler.GetCompanies)
+				r.Route("/{companyId}", func(r chi.Router) {
+					r.Get("/", companyHandler.GetCompany)
+					r.Put("/", companyHandler.UpdateCompany)
+					r.Delete("/", companyHandler.DeleteCompany)
+				})
+			})
+
+			r.Get("/search", searchHandler.Search)
+
+			r.Route("/tags", func(r chi.Router) {
+				r.Get("/", tagHandler.GetTags)
+				r.Post("/", tagHandler.CreateTag)
+				r.Route("/{tagId}", func(r chi.Router) {
+					r.Put("/", tagHandler.UpdateTag)
+					r.Delete("/", tagHandler.DeleteTag)
+					r.Post("/merge", tagHandler.MergeTag)
+				})
+			})
+
+			r.Route("/custom-fields", func(r chi.Router) {
+				r.Get("/", customFieldHandler.GetCustomFields)
+				r.Post("/", customFieldHandler.CreateCustomField)
+				r.Route("/{fieldId}", func(r chi.Router) {
+					r.Put("/", customFieldHandler.UpdateCustomField)
+					r.Delete("/", customFieldHandler.DeleteCustomField)
+				})
+			})
+
+			r.Route("/offers", func(r chi.Router) {
+				r.Get("/", offerHandler.GetOffers)
+				r.Get("/compare", offerHandler.CompareOffers)
+				r.Get("/deadlines", offerHandler.GetOfferDeadlines)
+			})
+
+			r.Route("/contacts", func(r chi.Router) {
+				r.Get("/", contactHandler.GetContacts)
+				r.Post("/", contactHandler.CreateContact)
+				r.Route("/{contactId}", func(r chi.Router) {
+					r.Get("/", contactHandler.GetContact)
+					r.Put("/", contactHandler.UpdateContact)
+					r.Delete("/", contactHandler.DeleteContact)
+				})
+			})
+
+			r.Route("/reminders", func(r chi.Router) {
+				r.Get("/", reminderHandler.GetReminders)
+				r.Get("/due", reminderHandler.GetDueReminders)
+				r.Route("/rules", func(r chi.Router) {
+					r.Get("/", reminderHandler.GetRules)
+					r.Post("/", reminderHandler.CreateRule)
+					r.Route("/{ruleId}", func(r chi.Router) {
+						r.Put("/", reminderHandler.UpdateRule)
+						r.Delete("/", reminderHandler.DeleteRule)
+					})
+				})
+				r.Route("/{reminderId}", func(r chi.Router) {
+					r.Put("/", reminderHandler.UpdateReminder)
+					r.Delete("/", reminderHandler.DeleteReminder)
+				})
+			})
+
+			r.Route("/calendar/token", func(r chi.Router) {
+				r.Post("/", calendarHandler.CreateFeedToken)
+				r.Delete("/", calendarHandler.RevokeFeedToken)
+			})
+
+			r.Route("/workflow", func(r chi.Router) {
+				r.Get("/", workflowHandler.GetWorkflow)
+				r.Put("/", workflowHandler.UpdateWorkflow)
+				r.Delete("/", workflowHandler.ResetWorkflow)
+			})
+
 			r.Post("/blog", blogHandler.CreateBlogPost)
 		})
 	})
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// legalSuffixes are dropped from the end of company names when normalizing,
+// so "Google", "google" and "Google LLC" are the same company.
+var legalSuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "ltd": true, "limited": true,
+	"corp": true, "corporation": true, "co": true, "gmbh": true, "plc": true,
+	"ag": true, "sa": true, "bv": true, "nv": true, "pty": true, "srl": true,
+	"oy": true, "ab": true,
+}
+
+// NormalizeCompanyName returns the key companies are matched on: lower case, dots
+// and apostrophes dropped ("B.V." is "bv"), other punctuation collapsed to single
+// spaces and trailing legal suffixes removed.
+// Migration 000010 backfills with an SQL copy of this function; keep them in sync.
+func NormalizeCompanyName(name string) string {
+	joined := strings.NewReplacer(".", "", "'", "").Replace(strings.ToLower(name))
+	words := strings.FieldsFunc(joined, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '&'
+	})
+	for len(words) > 1 && legalSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	if len(words) == 0 {
+		return strings.ToLower(strings.TrimSpace(name))
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationCursor marks the position after the last application of a page.
+// It carries the sort it was produced for so it cannot be replayed against another ordering.
+type ApplicationCursor struct {
+	Sort       ApplicationSort `json:"s"`
+	Descending bool            `json:"d"`
+	Key        string          `json:"k"` // Value of the sort column for the last row
+	ID         string          `json:"i"` // Tie-breaker
+}
+
+// Encode returns the opaque string handed to API clients.
+func (c ApplicationCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeApplicationCursor parses a cursor previously produced by Encode.
+func DecodeApplicationCursor(s string) (*ApplicationCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+	}
+	var c ApplicationCursor
+	// The ID goes into the query as a UUID, so anything else must be rejected here
+	if err := json.Unmarshal(raw, &c); err != nil || uuid.Validate(c.ID) != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+	}
+	return &c, nil
+}
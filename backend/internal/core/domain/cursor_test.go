@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestApplicationCursorRoundTrip(t *testing.T) {
+	cursors := []ApplicationCursor{
+		{Sort: SortByDate, Descending: true, Key: "2026-01-02", ID: "0b6a1f5e-3c1d-4d7e-9f2a-1c2b3d4e5f60"},
+		{Sort: SortByCompany, Key: "acme, \"inc\"", ID: "7c9e6679-7425-40de-944b-e07fc1f90ae7"},
+		{Sort: SortByUpdatedAt, Key: "", ID: "00000000-0000-0000-0000-000000000000"},
+	}
+	for _, want := range cursors {
+		got, err := DecodeApplicationCursor(want.Encode())
+		if err != nil {
+			t.Fatalf("DecodeApplicationCursor(%+v.Encode()) = %v", want, err)
+		}
+		if *got != want {
+			t.Errorf("round trip = %+v, want %+v", *got, want)
+		}
+	}
+}
+
+func TestDecodeApplicationCursorRejectsMalformed(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"empty", ""},
+		{"not base64", "not a cursor!"},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"i":"7c9e6679-7425-40de-944b-e07fc1f90ae7"}`))},
+		{"not JSON", encode("hello")},
+		{"wrong JSON type", encode(`["s","d"]`)},
+		{"missing ID", encode(`{"s":"date","d":true,"k":"2026-01-02"}`)},
+		{"ID not a UUID", encode(`{"s":"date","k":"2026-01-02","i":"1 OR 1=1"}`)},
+		{"ID of the wrong type", encode(`{"s":"date","k":"2026-01-02","i":42}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := DecodeApplicationCursor(tt.cursor)
+			if err == nil {
+				t.Fatalf("DecodeApplicationCursor(%q) = %+v, want an error", tt.cursor, cursor)
+			}
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("error %v does not wrap ErrInvalidInput", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrNotFound is wrapped when a resource doesn't exist, or belongs to another user.
+	ErrNotFound = errors.New("not found")
+
+	// ErrInvalidInput is wrapped by the service layer when a request fails validation,
+	// so handlers can answer with 400 instead of a generic error.
+	ErrInvalidInput = errors.New("invalid input")
+
+	// ErrConflict is wrapped when a request conflicts with the current state of a resource.
+	ErrConflict = errors.New("conflict")
+
+	// ErrInvalidTransition is wrapped when a status change is not allowed by the user's workflow.
+	ErrInvalidTransition = errors.New("invalid status transition")
+
+	// ErrRateLimited is wrapped when a client has made too many attempts in too short a time.
+	ErrRateLimited = errors.New("too many attempts")
+)
+
+// DuplicateError is returned when a new application looks like one the user
+// already has. It matches ErrConflict with errors.Is.
+type DuplicateError struct {
+	IDs []string // The existing applications it appears to duplicate
+}
+
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("%s: looks like a duplicate of %d existing application(s); pass force=true to create it anyway", ErrConflict, len(e.IDs))
+}
+
+func (e *DuplicateError) Unwrap() error {
+	return ErrConflict
+}
+
+// RateLimitError is returned when an attempt is refused for being one too many, e.g. a
+// login while the account is locked out. It matches ErrRateLimited with errors.Is.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s; try again in %s", ErrRateLimited, e.RetryAfter.Round(time.Second))
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
@@ -1,4 +1,3 @@
-
 package domain
 
 import "time"
@@ -6,10 +5,12 @@ import "time"
 // |--- User & Auth Models ---
 
 type User struct {
-	ID           string `json:"id"`
-	Username     string `json:"username"`
-	Email        string `json:"email"`
-	PasswordHash string `json:"-"` // Not exposed in API
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"emailVerified"`
+	PendingEmail  string `json:"pendingEmail,omitempty"` // Replaces Email once the link sent to it is opened
+	PasswordHash  string `json:"-"`                      // Not exposed in API
 }
 
 type UserRegistration struct {
@@ -24,25 +25,206 @@ type UserLogin struct {
 }
 
 type AuthResponse struct {
-	Token string `json:"token"`
-	User  *User  `json:"user"`
+	Token          string    `json:"token"` // Short-lived access token
+	TokenExpiresAt time.Time `json:"tokenExpiresAt"`
+	RefreshToken   string    `json:"refreshToken"` // Single use; each refresh returns a new one
+	User           *User     `json:"user"`
+}
+
+// UpdateProfileRequest changes the fields that are set. A new email only takes effect
+// once it is verified, and changing it requires the current password.
+type UpdateProfileRequest struct {
+	Username        *string `json:"username,omitempty"`
+	Email           *string `json:"email,omitempty"`
+	CurrentPassword string  `json:"currentPassword,omitempty"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" required:"true"`
+	NewPassword     string `json:"newPassword" required:"true"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" required:"true"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" required:"true"`
+	Password string `json:"password" required:"true"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" required:"true"`
+}
+
+type UserTokenPurpose string
+
+const (
+	PurposeVerifyEmail   UserTokenPurpose = "verify_email"
+	PurposeResetPassword UserTokenPurpose = "reset_password"
+	PurposeChangeEmail   UserTokenPurpose = "change_email" // Confirms the user's pending email
+)
+
+// UserToken is a single-use token emailed to a user, e.g. in a password reset link.
+type UserToken struct {
+	ID        string
+	UserID    string
+	Purpose   UserTokenPurpose
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+type LoginFailureReason string
+
+const (
+	LoginUnknownUser   LoginFailureReason = "unknown_user"
+	LoginWrongPassword LoginFailureReason = "wrong_password"
+	LoginLockedOut     LoginFailureReason = "locked_out"
+)
+
+// LoginAttempt is an audit record of a failed login.
+type LoginAttempt struct {
+	ID        string
+	Username  string // As typed, lowercased
+	UserID    string // Empty when no such user exists
+	IP        string
+	UserAgent string
+	Reason    LoginFailureReason
+	CreatedAt time.Time
+}
+
+// LoginLockout tracks consecutive failed logins for a username.
+type LoginLockout struct {
+	Username      string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" required:"true"`
+}
+
+// Session is one logged-in device. Its refresh token rotates on every use, but the
+// session, and the ID access tokens carry, stays the same until it is revoked or expires.
+type Session struct {
+	ID        string `json:"id"`
+	UserID    string `json:"-"`
+	TokenHash string `json:"-"` // Hash of the current refresh token's secret
+	// PreviousTokenHashes are the hashes of the secrets it replaced, newest first, so a
+	// replayed one can be told apart from a guess. RotatedAt is when the last one was replaced.
+	PreviousTokenHashes []string   `json:"-"`
+	RotatedAt           *time.Time `json:"-"`
+	UserAgent           string     `json:"userAgent"`
+	IP                  string     `json:"ip"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	LastUsedAt          time.Time  `json:"lastUsedAt"`
+	ExpiresAt           time.Time  `json:"expiresAt"`
+	RevokedAt           *time.Time `json:"-"`
+	Current             bool       `json:"current"` // Whether the listing was requested from this session
+}
+
+// SessionClient describes where a login or refresh came from.
+type SessionClient struct {
+	UserAgent string
+	IP        string
 }
 
 // |--- Application Models ---
 
 type ApplicationStatus string
 
+// The statuses of the default workflow. Users can define their own in a Workflow;
+// Archived is reserved and always available.
 const (
-	StatusApplied     ApplicationStatus = "Applied"
+	StatusApplied      ApplicationStatus = "Applied"
+	StatusPhoneScreen  ApplicationStatus = "Phone Screen"
+	StatusTakeHome     ApplicationStatus = "Take-home"
 	StatusInterviewing ApplicationStatus = "Interviewing"
-	StatusOffer       ApplicationStatus = "Offer"
-	StatusRejected    ApplicationStatus = "Rejected"
-	StatusArchived    ApplicationStatus = "Archived"
+	StatusOnsite       ApplicationStatus = "Onsite"
+	StatusOffer        ApplicationStatus = "Offer"
+	StatusRejected     ApplicationStatus = "Rejected"
+	StatusWithdrawn    ApplicationStatus = "Withdrawn"
+	StatusGhosted      ApplicationStatus = "Ghosted"
+	StatusArchived     ApplicationStatus = "Archived"
+)
+
+type WorkMode string
+
+const (
+	WorkModeRemote WorkMode = "remote"
+	WorkModeHybrid WorkMode = "hybrid"
+	WorkModeOnsite WorkMode = "onsite"
+)
+
+type EmploymentType string
+
+const (
+	EmploymentFullTime   EmploymentType = "full_time"
+	EmploymentPartTime   EmploymentType = "part_time"
+	EmploymentContract   EmploymentType = "contract"
+	EmploymentInternship EmploymentType = "internship"
+	EmploymentTemporary  EmploymentType = "temporary"
+)
+
+// ApplicationSource is where the user found the job.
+type ApplicationSource string
+
+const (
+	SourceLinkedIn    ApplicationSource = "linkedin"
+	SourceReferral    ApplicationSource = "referral"
+	SourceCompanySite ApplicationSource = "company_site"
+	SourceJobBoard    ApplicationSource = "job_board"
+	SourceRecruiter   ApplicationSource = "recruiter"
+	SourceOther       ApplicationSource = "other"
 )
 
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityMedium Priority = "medium"
+	PriorityHigh   Priority = "high"
+)
+
+// JobPosting holds the optional details of the advertised position. It is
+// embedded in Application and NewApplication, so its fields appear inline in JSON.
+type JobPosting struct {
+	JobURL         string            `json:"jobUrl,omitempty"`
+	Location       string            `json:"location,omitempty"`
+	WorkMode       WorkMode          `json:"workMode,omitempty"`
+	SalaryMin      *int              `json:"salaryMin,omitempty"` // Whole units of SalaryCurrency
+	SalaryMax      *int              `json:"salaryMax,omitempty"`
+	SalaryCurrency string            `json:"salaryCurrency,omitempty"` // ISO 4217, e.g. "EUR"
+	EmploymentType EmploymentType    `json:"employmentType,omitempty"`
+	Source         ApplicationSource `json:"source,omitempty"`
+	Priority       Priority          `json:"priority,omitempty"`
+	JobDescription string            `json:"jobDescription,omitempty"`
+}
+
+type HistoryEventType string
+
+const (
+	EventCreated       HistoryEventType = "created"
+	EventStatusChanged HistoryEventType = "status_changed"
+	EventFieldEdited   HistoryEventType = "field_edited"
+	EventNoteAdded     HistoryEventType = "note_added"
+	EventArchived      HistoryEventType = "archived"
+	EventRestored      HistoryEventType = "restored"
+)
+
+// HistoryEvent records one change to an application. Event is the human
+// readable summary; the other fields carry the same information in structured form.
 type HistoryEvent struct {
-	Date  time.Time `json:"date"`
-	Event string    `json:"event"`
+	Date       time.Time         `json:"date"`
+	Type       HistoryEventType  `json:"type"`
+	FromStatus ApplicationStatus `json:"fromStatus,omitempty"`
+	ToStatus   ApplicationStatus `json:"toStatus,omitempty"`
+	Fields     []string          `json:"fields,omitempty"` // Changed fields, for field_edited events
+	Reason     string            `json:"reason,omitempty"` // Why the status changed, when the workflow asks for it
+	Event      string            `json:"event"`
 }
 
 type Note struct {
@@ -55,12 +237,17 @@ type Application struct {
 	ID        string            `json:"id"`
 	UserID    string            `json:"-"` // Internal use
 	Company   string            `json:"company"`
+	CompanyID string            `json:"companyId"` // Resolved from Company by the repository
 	Role      string            `json:"role"`
 	Date      string            `json:"date"` // Format: YYYY-MM-DD
 	UpdatedAt string            `json:"updatedAt"`
 	Status    ApplicationStatus `json:"status"`
-	Notes     []Note            `json:"notes"`
-	History   []HistoryEvent    `json:"history"`
+	JobPosting
+	CustomFields CustomFieldValues    `json:"customFields"`
+	Tags         []Tag                `json:"tags"`
+	Notes        []Note               `json:"notes"`
+	History      []HistoryEvent       `json:"history"`
+	Contacts     []ApplicationContact `json:"contacts,omitempty"` // Only filled in on the detail view
 }
 
 type NewApplication struct {
@@ -68,19 +255,695 @@ type NewApplication struct {
 	Role    string            `json:"role" required:"true"`
 	Date    string            `json:"date" required:"true"`
 	Status  ApplicationStatus `json:"status" required:"true"`
+	JobPosting
+	CustomFields CustomFieldValues `json:"customFields"`
 }
 
 type ApplicationUpdate struct {
-	Company *string            `json:"company,omitempty"`
-	Role    *string            `json:"role,omitempty"`
-	Date    *string            `json:"date,omitempty"`
-	Status  *ApplicationStatus `json:"status,omitempty"`
+	Company      *string            `json:"company,omitempty"`
+	Role         *string            `json:"role,omitempty"`
+	Date         *string            `json:"date,omitempty"`
+	Status       *ApplicationStatus `json:"status,omitempty"`
+	StatusReason *string            `json:"statusReason,omitempty"`
+
+	// Job posting details. Empty strings clear a field, as does 0 for a salary bound.
+	JobURL         *string            `json:"jobUrl,omitempty"`
+	Location       *string            `json:"location,omitempty"`
+	WorkMode       *WorkMode          `json:"workMode,omitempty"`
+	SalaryMin      *int               `json:"salaryMin,omitempty"`
+	SalaryMax      *int               `json:"salaryMax,omitempty"`
+	SalaryCurrency *string            `json:"salaryCurrency,omitempty"`
+	EmploymentType *EmploymentType    `json:"employmentType,omitempty"`
+	Source         *ApplicationSource `json:"source,omitempty"`
+	Priority       *Priority          `json:"priority,omitempty"`
+	JobDescription *string            `json:"jobDescription,omitempty"`
+
+	// CustomFields sets the given values and leaves the others alone. A null value clears the field.
+	CustomFields CustomFieldValues `json:"customFields,omitempty"`
+}
+
+// |--- Application Listing ---
+
+type ApplicationSort string
+
+const (
+	SortByDate      ApplicationSort = "date"
+	SortByUpdatedAt ApplicationSort = "updatedAt"
+	SortByCompany   ApplicationSort = "company"
+)
+
+// ApplicationFilter narrows and orders the applications returned by ApplicationRepository.List.
+// Zero values mean "no constraint", except that archived applications are
+// only returned when IncludeArchived is set.
+type ApplicationFilter struct {
+	Statuses        []ApplicationStatus
+	IncludeArchived bool
+	Company         string   // Case-insensitive substring
+	Role            string   // Case-insensitive substring
+	DateFrom        string   // Inclusive, format: YYYY-MM-DD
+	DateTo          string   // Inclusive, format: YYYY-MM-DD
+	UpdatedBefore   string   // Exclusive, format: YYYY-MM-DD
+	Tags            []string // Tag names, case-insensitive; matches applications with any of them
+	// CustomFields holds exact matches on custom field values, keyed by field key. The
+	// handler passes the raw query strings; ApplicationService converts them to the field's type.
+	CustomFields CustomFieldValues
+	Sort         ApplicationSort
+	Descending   bool
+	Cursor       *ApplicationCursor
+	Limit        int
+	WithDetails  bool // Also load notes and history, which listings normally leave out
+}
+
+type ApplicationPage struct {
+	Applications []*Application
+	NextCursor   *ApplicationCursor // nil on the last page
+}
+
+// RespondedStatuses are the statuses that count as having heard back from the
+// employer when computing the response rate.
+var RespondedStatuses = []ApplicationStatus{
+	StatusPhoneScreen, StatusTakeHome, StatusInterviewing, StatusOnsite, StatusOffer, StatusRejected,
+}
+
+type WeeklyCount struct {
+	WeekStart string `json:"weekStart"` // Format: YYYY-MM-DD (Monday)
+	Count     int    `json:"count"`
+}
+
+// ApplicationStats is the dashboard summary of a user's applications.
+// ResponseRate is the share of non-archived applications in a RespondedStatuses status.
+type ApplicationStats struct {
+	Total               int                       `json:"total"`
+	Active              int                       `json:"active"`
+	StatusBreakdown     map[ApplicationStatus]int `json:"statusBreakdown"`
+	ResponseRate        float64                   `json:"responseRate"`
+	ApplicationsPerWeek []WeeklyCount             `json:"applicationsPerWeek"`
+}
+
+// |--- Interview Models ---
+
+type InterviewType string
+
+const (
+	InterviewPhone      InterviewType = "phone"
+	InterviewVideo      InterviewType = "video"
+	InterviewOnsite     InterviewType = "onsite"
+	InterviewTechnical  InterviewType = "technical"
+	InterviewBehavioral InterviewType = "behavioral"
+	InterviewTakeHome   InterviewType = "take_home"
+	InterviewOther      InterviewType = "other"
+)
+
+type InterviewOutcome string
+
+const (
+	OutcomePending   InterviewOutcome = "pending"
+	OutcomePassed    InterviewOutcome = "passed"
+	OutcomeFailed    InterviewOutcome = "failed"
+	OutcomeCancelled InterviewOutcome = "cancelled"
+)
+
+type Interview struct {
+	ID              string           `json:"id"`
+	ApplicationID   string           `json:"applicationId"`
+	UserID          string           `json:"-"` // Internal use
+	RoundName       string           `json:"roundName"`
+	Type            InterviewType    `json:"type"`
+	ScheduledAt     time.Time        `json:"scheduledAt"`
+	Timezone        string           `json:"timezone"` // IANA name, e.g. "Europe/Berlin"
+	DurationMinutes int              `json:"durationMinutes"`
+	Interviewers    []string         `json:"interviewers"`
+	Location        string           `json:"location"`
+	MeetingURL      string           `json:"meetingUrl"`
+	Outcome         InterviewOutcome `json:"outcome"`
+	Feedback        string           `json:"feedback"`
+	CreatedAt       time.Time        `json:"createdAt"`
+	UpdatedAt       time.Time        `json:"updatedAt"`
+}
+
+type NewInterview struct {
+	RoundName       string        `json:"roundName" required:"true"`
+	Type            InterviewType `json:"type" required:"true"`
+	ScheduledAt     time.Time     `json:"scheduledAt" required:"true"`
+	Timezone        string        `json:"timezone"`        // Defaults to UTC
+	DurationMinutes int           `json:"durationMinutes"` // Defaults to 60
+	Interviewers    []string      `json:"interviewers"`
+	Location        string        `json:"location"`
+	MeetingURL      string        `json:"meetingUrl"`
+}
+
+type InterviewUpdate struct {
+	RoundName       *string           `json:"roundName,omitempty"`
+	Type            *InterviewType    `json:"type,omitempty"`
+	ScheduledAt     *time.Time        `json:"scheduledAt,omitempty"`
+	Timezone        *string           `json:"timezone,omitempty"`
+	DurationMinutes *int              `json:"durationMinutes,omitempty"`
+	Interviewers    *[]string         `json:"interviewers,omitempty"`
+	Location        *string           `json:"location,omitempty"`
+	MeetingURL      *string           `json:"meetingUrl,omitempty"`
+	Outcome         *InterviewOutcome `json:"outcome,omitempty"`
+	Feedback        *string           `json:"feedback,omitempty"`
+}
+
+// |--- Reminder Models ---
+
+type Reminder struct {
+	ID            string     `json:"id"`
+	UserID        string     `json:"-"` // Internal use
+	ApplicationID string     `json:"applicationId"`
+	DueAt         time.Time  `json:"dueAt"`
+	Message       string     `json:"message"`
+	Done          bool       `json:"done"`
+	RuleID        string     `json:"ruleId,omitempty"` // Set when a FollowUpRule created the reminder
+	RuleAnchor    string     `json:"-"`                // The application's updatedAt when the rule fired
+	NotifiedAt    *time.Time `json:"notifiedAt,omitempty"`
+	// DeliveryAttempts counts failed deliveries; NextAttemptAt is when the next one may happen
+	DeliveryAttempts int        `json:"deliveryAttempts,omitempty"`
+	NextAttemptAt    *time.Time `json:"-"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
+
+type NewReminder struct {
+	DueAt   time.Time `json:"dueAt" required:"true"`
+	Message string    `json:"message" required:"true"`
+}
+
+type ReminderUpdate struct {
+	DueAt   *time.Time `json:"dueAt,omitempty"`
+	Message *string    `json:"message,omitempty"`
+	Done    *bool      `json:"done,omitempty"`
+}
+
+// FollowUpRule asks for a reminder once an application has been in Status
+// for AfterDays days since its last update.
+type FollowUpRule struct {
+	ID        string            `json:"id"`
+	UserID    string            `json:"-"` // Internal use
+	Status    ApplicationStatus `json:"status"`
+	AfterDays int               `json:"afterDays"`
+	Message   string            `json:"message,omitempty"` // Defaults to a generated follow-up prompt
+	Enabled   bool              `json:"enabled"`
+}
+
+type NewFollowUpRule struct {
+	Status    ApplicationStatus `json:"status"` // Defaults to Applied
+	AfterDays int               `json:"afterDays" required:"true"`
+	Message   string            `json:"message"`
+	Enabled   *bool             `json:"enabled"` // Defaults to true
+}
+
+type FollowUpRuleUpdate struct {
+	Status    *ApplicationStatus `json:"status,omitempty"`
+	AfterDays *int               `json:"afterDays,omitempty"`
+	Message   *string            `json:"message,omitempty"`
+	Enabled   *bool              `json:"enabled,omitempty"`
+}
+
+// |--- Company Models ---
+
+// Company is created implicitly the first time a user applies to it; applications
+// are matched to it by NormalizeCompanyName.
+type Company struct {
+	ID             string    `json:"id"`
+	UserID         string    `json:"-"` // Internal use
+	Name           string    `json:"name"`
+	NormalizedName string    `json:"normalizedName"`
+	Website        string    `json:"website,omitempty"`
+	Industry       string    `json:"industry,omitempty"`
+	Size           string    `json:"size,omitempty"` // Free text, e.g. "51-200"
+	Location       string    `json:"location,omitempty"`
+	Notes          string    `json:"notes,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type CompanyUpdate struct {
+	Name     *string `json:"name,omitempty"`
+	Website  *string `json:"website,omitempty"`
+	Industry *string `json:"industry,omitempty"`
+	Size     *string `json:"size,omitempty"`
+	Location *string `json:"location,omitempty"`
+	Notes    *string `json:"notes,omitempty"`
+}
+
+// CompanySummary is a company with a rollup of the user's applications to it.
+type CompanySummary struct {
+	Company
+	ApplicationCount int                       `json:"applicationCount"`
+	StatusBreakdown  map[ApplicationStatus]int `json:"statusBreakdown"`
+	LastAppliedOn    string                    `json:"lastAppliedOn,omitempty"` // Format: YYYY-MM-DD
+}
+
+type CompanyDetail struct {
+	CompanySummary
+	Applications []*Application `json:"applications"`
+}
+
+// |--- Contact Models ---
+
+type Contact struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"-"` // Internal use
+	Name        string    `json:"name"`
+	Email       string    `json:"email,omitempty"`
+	Phone       string    `json:"phone,omitempty"`
+	LinkedInURL string    `json:"linkedinUrl,omitempty"`
+	Company     string    `json:"company,omitempty"`
+	Title       string    `json:"title,omitempty"`
+	Notes       string    `json:"notes,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type NewContact struct {
+	Name        string `json:"name" required:"true"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+	LinkedInURL string `json:"linkedinUrl"`
+	Company     string `json:"company"`
+	Title       string `json:"title"`
+	Notes       string `json:"notes"`
+}
+
+type ContactUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Email       *string `json:"email,omitempty"`
+	Phone       *string `json:"phone,omitempty"`
+	LinkedInURL *string `json:"linkedinUrl,omitempty"`
+	Company     *string `json:"company,omitempty"`
+	Title       *string `json:"title,omitempty"`
+	Notes       *string `json:"notes,omitempty"`
+}
+
+// ContactRole describes how a contact is involved in a particular application.
+type ContactRole string
+
+const (
+	ContactRecruiter     ContactRole = "recruiter"
+	ContactHiringManager ContactRole = "hiring_manager"
+	ContactReferrer      ContactRole = "referrer"
+	ContactInterviewer   ContactRole = "interviewer"
+	ContactOther         ContactRole = "other"
+)
+
+// ApplicationContact is a contact as linked to one application.
+type ApplicationContact struct {
+	Contact
+	Role ContactRole `json:"role"`
+}
+
+// |--- Bulk Operations ---
+
+type BulkOperation string
+
+const (
+	BulkSetStatus BulkOperation = "set_status"
+	BulkArchive   BulkOperation = "archive"
+	BulkRestore   BulkOperation = "restore"
+	BulkAddTag    BulkOperation = "add_tag"
+	BulkDelete    BulkOperation = "delete"
+)
+
+// BulkRequest applies one operation to many applications. Status and StatusReason are
+// used by set_status, TagID by add_tag.
+type BulkRequest struct {
+	IDs          []string           `json:"ids"`
+	Operation    BulkOperation      `json:"operation"`
+	Status       *ApplicationStatus `json:"status,omitempty"`
+	StatusReason string             `json:"statusReason,omitempty"`
+	TagID        string             `json:"tagId,omitempty"`
+}
+
+type BulkItemStatus string
+
+const (
+	BulkItemUpdated   BulkItemStatus = "updated"
+	BulkItemDeleted   BulkItemStatus = "deleted"
+	BulkItemUnchanged BulkItemStatus = "unchanged" // Already in the requested state
+	BulkItemFailed    BulkItemStatus = "failed"
+)
+
+type BulkItemResult struct {
+	ID     string         `json:"id"`
+	Status BulkItemStatus `json:"status"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// BulkResult reports what happened to each application, in request order. A bulk
+// operation is all or nothing: if any item failed, Applied is false and nothing was changed.
+type BulkResult struct {
+	Applied bool             `json:"applied"`
+	Results []BulkItemResult `json:"results"`
+}
+
+// ApplicationBatch is the set of changes a bulk operation or import makes, written in one transaction.
+type ApplicationBatch struct {
+	Tags           []*Tag         // Created before the applications, so assignments can use them
+	CustomFields   []*CustomField // Created before the applications
+	Creates        []*Application
+	Updates        []*Application
+	Deletes        []string // Application IDs
+	TagAssignments []TagAssignment
+}
+
+type TagAssignment struct {
+	ApplicationID string
+	TagID         string
+}
+
+// |--- Import ---
+
+// ImportMapping names the CSV column holding each application field. Fields left empty
+// are matched against the header by their usual names ("Company", "Position", "Applied on", ...).
+type ImportMapping struct {
+	Company  string `json:"company,omitempty"`
+	Role     string `json:"role,omitempty"`
+	Date     string `json:"date,omitempty"`
+	Status   string `json:"status,omitempty"`
+	JobURL   string `json:"jobUrl,omitempty"`
+	Location string `json:"location,omitempty"`
+	Notes    string `json:"notes,omitempty"` // Becomes the application's first note
+}
+
+type ImportRequest struct {
+	CSV      string        `json:"csv"` // Including the header row
+	FileName string        `json:"fileName,omitempty"`
+	Mapping  ImportMapping `json:"mapping"`
+	// DateFormat is a pattern like "DD/MM/YYYY" or "MMM D, YYYY". It is detected from the
+	// dates in the file when empty.
+	DateFormat string `json:"dateFormat,omitempty"`
+	// StatusMap maps free-text status values, ignoring case, to workflow statuses. Values
+	// not listed are matched against the workflow and common synonyms.
+	StatusMap map[string]ApplicationStatus `json:"statusMap,omitempty"`
+	DryRun    bool                         `json:"dryRun"`
+	Force     bool                         `json:"force"` // Import likely duplicates instead of skipping them
+}
+
+type ImportRowResult string
+
+const (
+	ImportValid     ImportRowResult = "valid"
+	ImportDuplicate ImportRowResult = "duplicate" // Skipped unless the request forces it
+	ImportInvalid   ImportRowResult = "invalid"
+)
+
+// ImportRow is one parsed row of the file. Line is its line number in the CSV.
+type ImportRow struct {
+	Line          int               `json:"line"`
+	Company       string            `json:"company"`
+	Role          string            `json:"role"`
+	Date          string            `json:"date"` // YYYY-MM-DD once parsed
+	Status        ApplicationStatus `json:"status"`
+	Result        ImportRowResult   `json:"result"`
+	Errors        []string          `json:"errors,omitempty"`
+	Duplicates    []string          `json:"duplicates,omitempty"`    // Existing application IDs, or "line N" for earlier rows
+	ApplicationID string            `json:"applicationId,omitempty"` // Set once imported
+}
+
+// ImportResult reports how a file was read and what happened to each row. Nothing is
+// imported on a dry run, nor when any row is invalid.
+type ImportResult struct {
+	DryRun     bool        `json:"dryRun"`
+	Imported   bool        `json:"imported"`
+	DateFormat string      `json:"dateFormat"`
+	Rows       []ImportRow `json:"rows"`
+	Created    int         `json:"created"`
+	Skipped    int         `json:"skipped"` // Duplicates left out
+}
+
+// |--- Export ---
+
+// BackupVersion is the version of the JSON backup format written by the export.
+const BackupVersion = 1
+
+// Backup is the JSON export: a user's applications with everything needed to recreate
+// them in another account, including the tags and custom fields they use.
+type Backup struct {
+	Version      int            `json:"version"`
+	ExportedAt   time.Time      `json:"exportedAt"`
+	Tags         []Tag          `json:"tags"`
+	CustomFields []*CustomField `json:"customFields"`
+	Applications []*Application `json:"applications"`
+}
+
+type RestoreResult struct {
+	Applications int `json:"applications"`
+	Tags         int `json:"tags"`         // Created; tags with a name the account already has are reused
+	CustomFields int `json:"customFields"` // Likewise, matched by key
+}
+
+// |--- Search Models ---
+
+// SearchSource is the part of an application a search match was found in.
+type SearchSource string
+
+const (
+	SourceApplication SearchSource = "application" // Company or role
+	SourceNote        SearchSource = "note"
+	SourceHistory     SearchSource = "history"
+)
+
+type SearchMatch struct {
+	Source  SearchSource `json:"source"`
+	NoteID  string       `json:"noteId,omitempty"`
+	Snippet string       `json:"snippet"` // HTML-escaped, with matched words wrapped in <mark></mark>
+}
+
+// SearchResult is one application matching a search, with every place it matched, best first.
+type SearchResult struct {
+	ApplicationID string            `json:"applicationId"`
+	Company       string            `json:"company"`
+	Role          string            `json:"role"`
+	Status        ApplicationStatus `json:"status"`
+	Rank          float64           `json:"rank"`
+	Matches       []SearchMatch     `json:"matches"`
+}
+
+// |--- Custom Field Models ---
+
+type CustomFieldType string
+
+const (
+	FieldText   CustomFieldType = "text"
+	FieldNumber CustomFieldType = "number"
+	FieldDate   CustomFieldType = "date"
+	FieldEnum   CustomFieldType = "enum"
+	FieldBool   CustomFieldType = "bool"
+)
+
+// CustomField is a user-defined field on their applications. Values are stored under
+// Key, which is fixed at creation so renaming the field keeps its values.
+type CustomField struct {
+	ID        string          `json:"id"`
+	UserID    string          `json:"-"` // Internal use
+	Key       string          `json:"key"`
+	Name      string          `json:"name"`
+	Type      CustomFieldType `json:"type"`
+	Options   []string        `json:"options,omitempty"` // The allowed values of an enum
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+}
+
+type NewCustomField struct {
+	Name    string          `json:"name" required:"true"`
+	Key     string          `json:"key"` // Derived from the name if empty
+	Type    CustomFieldType `json:"type" required:"true"`
+	Options []string        `json:"options"`
+}
+
+// CustomFieldUpdate can't change the type; delete the field and add a new one instead.
+type CustomFieldUpdate struct {
+	Name    *string   `json:"name,omitempty"`
+	Options *[]string `json:"options,omitempty"`
+}
+
+// CustomFieldValues maps field keys to values: a string for text, enum and date
+// (YYYY-MM-DD) fields, a float64 for numbers and a bool for bools.
+type CustomFieldValues map[string]any
+
+// |--- Tag Models ---
+
+// Tag is a user-defined label. Applications and tags are many-to-many.
+type Tag struct {
+	ID     string `json:"id"`
+	UserID string `json:"-"` // Internal use
+	Name   string `json:"name"`
+	Color  string `json:"color"` // Format: #rrggbb
+}
+
+type NewTag struct {
+	Name  string `json:"name" required:"true"`
+	Color string `json:"color"` // Defaults to grey
+}
+
+type TagUpdate struct {
+	Name  *string `json:"name,omitempty"`
+	Color *string `json:"color,omitempty"`
+}
+
+type TagSummary struct {
+	Tag
+	ApplicationCount int `json:"applicationCount"`
+}
+
+// |--- Offer Models ---
+
+// PayPeriod is what an offer's base salary is quoted per.
+type PayPeriod string
+
+const (
+	PayHourly  PayPeriod = "hourly"
+	PayMonthly PayPeriod = "monthly"
+	PayYearly  PayPeriod = "yearly"
+)
+
+type EquityType string
+
+const (
+	EquityRSU     EquityType = "rsu"
+	EquityOptions EquityType = "options"
+	EquityOther   EquityType = "other"
+)
+
+// VestingSchedule spreads an equity grant over Years, with nothing vesting before the cliff.
+type VestingSchedule struct {
+	Years       int `json:"years"`
+	CliffMonths int `json:"cliffMonths"`
+}
+
+// Equity is a grant whose Value is its total estimated worth in the offer's currency.
+type Equity struct {
+	Type    EquityType      `json:"type"`
+	Shares  int             `json:"shares,omitempty"`
+	Value   int             `json:"value"`
+	Vesting VestingSchedule `json:"vesting"`
+}
+
+type OfferDecision string
+
+const (
+	DecisionPending  OfferDecision = "pending"
+	DecisionAccepted OfferDecision = "accepted"
+	DecisionDeclined OfferDecision = "declined"
+)
+
+type NegotiationParty string
+
+const (
+	PartyCandidate NegotiationParty = "candidate"
+	PartyEmployer  NegotiationParty = "employer"
+)
+
+// NegotiationEntry is one round of back-and-forth on an offer.
+type NegotiationEntry struct {
+	Date       time.Time        `json:"date"`
+	Party      NegotiationParty `json:"party"`
+	BaseSalary *int             `json:"baseSalary,omitempty"` // What was asked for or offered in this round
+	Note       string           `json:"note"`
+}
+
+// Offer is the compensation package on an application. An application has at most one.
+type Offer struct {
+	ID               string             `json:"id"`
+	ApplicationID    string             `json:"applicationId"`
+	UserID           string             `json:"-"`        // Internal use
+	Currency         string             `json:"currency"` // ISO 4217
+	BaseSalary       int                `json:"baseSalary"`
+	PayPeriod        PayPeriod          `json:"payPeriod"`
+	Bonus            int                `json:"bonus"` // Annual target bonus
+	SigningBonus     int                `json:"signingBonus"`
+	Equity           *Equity            `json:"equity,omitempty"`
+	Benefits         []string           `json:"benefits"`
+	StartDate        string             `json:"startDate,omitempty"` // Format: YYYY-MM-DD
+	DecisionDeadline *time.Time         `json:"decisionDeadline,omitempty"`
+	Decision         OfferDecision      `json:"decision"`
+	Negotiations     []NegotiationEntry `json:"negotiations"`
+	Notes            string             `json:"notes"`
+	CreatedAt        time.Time          `json:"createdAt"`
+	UpdatedAt        time.Time          `json:"updatedAt"`
+}
+
+type NewOffer struct {
+	Currency         string     `json:"currency" required:"true"`
+	BaseSalary       int        `json:"baseSalary" required:"true"`
+	PayPeriod        PayPeriod  `json:"payPeriod"` // Defaults to yearly
+	Bonus            int        `json:"bonus"`
+	SigningBonus     int        `json:"signingBonus"`
+	Equity           *Equity    `json:"equity"`
+	Benefits         []string   `json:"benefits"`
+	StartDate        string     `json:"startDate"`
+	DecisionDeadline *time.Time `json:"decisionDeadline"`
+	Notes            string     `json:"notes"`
+}
+
+// OfferUpdate replaces the fields that are set. A null equity can't be told apart
+// from a missing one, so RemoveEquity drops the grant instead.
+type OfferUpdate struct {
+	Currency         *string        `json:"currency,omitempty"`
+	BaseSalary       *int           `json:"baseSalary,omitempty"`
+	PayPeriod        *PayPeriod     `json:"payPeriod,omitempty"`
+	Bonus            *int           `json:"bonus,omitempty"`
+	SigningBonus     *int           `json:"signingBonus,omitempty"`
+	Equity           *Equity        `json:"equity,omitempty"`
+	RemoveEquity     bool           `json:"removeEquity,omitempty"`
+	Benefits         *[]string      `json:"benefits,omitempty"`
+	StartDate        *string        `json:"startDate,omitempty"`
+	DecisionDeadline *time.Time     `json:"decisionDeadline,omitempty"`
+	Decision         *OfferDecision `json:"decision,omitempty"`
+	Notes            *string        `json:"notes,omitempty"`
+}
+
+type NewNegotiationEntry struct {
+	Party      NegotiationParty `json:"party" required:"true"`
+	BaseSalary *int             `json:"baseSalary"`
+	Note       string           `json:"note"`
+}
+
+// OfferComparison is an offer's compensation normalized to yearly amounts. Amounts are
+// in the offer's own currency; offers in different currencies are not converted.
+type OfferComparison struct {
+	OfferID          string        `json:"offerId"`
+	ApplicationID    string        `json:"applicationId"`
+	Company          string        `json:"company"`
+	Role             string        `json:"role"`
+	Currency         string        `json:"currency"`
+	AnnualBase       int           `json:"annualBase"`
+	AnnualBonus      int           `json:"annualBonus"`
+	AnnualEquity     int           `json:"annualEquity"`   // Grant value spread evenly over the vesting years
+	AnnualTotal      int           `json:"annualTotal"`    // Base + bonus + equity, recurring
+	FirstYearTotal   int           `json:"firstYearTotal"` // Annual total plus signing bonus, less equity still behind the cliff
+	Benefits         []string      `json:"benefits"`
+	DecisionDeadline *time.Time    `json:"decisionDeadline,omitempty"`
+	Decision         OfferDecision `json:"decision"`
+}
+
+// OfferDeadline is a pending offer whose decision deadline is coming up.
+type OfferDeadline struct {
+	Offer
+	Company string `json:"company"`
+	Role    string `json:"role"`
+}
+
+// |--- Workflow Models ---
+
+type WorkflowTransition struct {
+	From           ApplicationStatus `json:"from"`
+	To             ApplicationStatus `json:"to"`
+	RequiresReason bool              `json:"requiresReason,omitempty"`
+}
+
+// Workflow lists the statuses a user's applications can be in and the moves allowed between them.
+type Workflow struct {
+	Statuses    []ApplicationStatus  `json:"statuses"`
+	Transitions []WorkflowTransition `json:"transitions"`
 }
 
 // |--- Blog Models ---
 
 type Comment struct {
 	ID        string    `json:"id"`
+	AuthorID  string    `json:"-"` // Internal use
 	Author    string    `json:"author"`
 	Avatar    string    `json:"avatar"`
 	Content   string    `json:"content"`
@@ -94,6 +957,7 @@ type BlogPost struct {
 	Slug         string    `json:"slug"`
 	Title        string    `json:"title"`
 	Content      string    `json:"content"`
+	AuthorID     string    `json:"-"` // Internal use
 	Author       string    `json:"author"`
 	AuthorAvatar string    `json:"authorAvatar"`
 	CreatedAt    time.Time `json:"createdAt"`
@@ -1,21 +1,204 @@
-
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	GetByUsername(ctx context.Context, username string) (*User, error)
 	GetByID(ctx context.Context, id string) (*User, error)
+	// Update saves the username, email, verification status and pending email, and renames
+	// the user on their blog posts and comments. A taken username or email is an ErrConflict.
+	Update(ctx context.Context, user *User) error
+	UpdatePassword(ctx context.Context, id, passwordHash string) error
+	MarkEmailVerified(ctx context.Context, id string) error
+	// Delete removes the user and everything they own (applications, notes,
+	// history, blog posts and comments) in one transaction.
+	Delete(ctx context.Context, id string) error
 }
 
 type ApplicationRepository interface {
 	Create(ctx context.Context, app *Application) error
-	GetAllByUserID(ctx context.Context, userID string) ([]*Application, error)
+	List(ctx context.Context, userID string, filter ApplicationFilter) (*ApplicationPage, error)
 	GetByID(ctx context.Context, id string) (*Application, error)
+	ListByCompanyID(ctx context.Context, companyID string) ([]*Application, error) // Including archived, with notes and history
+	// FindByCompanyName returns the user's applications, archived included, to the company
+	// with the given normalized name. Notes, history and tags are not loaded.
+	FindByCompanyName(ctx context.Context, userID, normalizedName string) ([]*Application, error)
 	Update(ctx context.Context, app *Application) error
-	Delete(ctx context.Context, id string) error // In this case, we know it's a soft delete (archive)
+	Delete(ctx context.Context, id string) error // Hard delete; notes and history go with it. Archiving is an Update.
+	// ApplyBatch writes every create, update, delete and tag assignment of the batch in one
+	// transaction; if any of them fails, none are kept.
+	ApplyBatch(ctx context.Context, batch ApplicationBatch) error
+	// LockAndApplyBatch loads the applications with the given IDs, with tags, notes and
+	// history, and locks them against concurrent writes. It then applies the batch plan
+	// builds from them in the same transaction. IDs that don't exist are left out of what
+	// plan gets; an error from plan is returned and nothing is written.
+	LockAndApplyBatch(ctx context.Context, ids []string, plan func([]*Application) (ApplicationBatch, error)) error
+	GetStatsByUserID(ctx context.Context, userID string) (*ApplicationStats, error)
+}
+
+type InterviewRepository interface {
+	Create(ctx context.Context, interview *Interview) error
+	GetByID(ctx context.Context, id string) (*Interview, error)
+	ListByApplicationID(ctx context.Context, applicationID string) ([]*Interview, error)
+	ListByUserID(ctx context.Context, userID string, since time.Time) ([]*Interview, error) // Scheduled at or after since
+	Update(ctx context.Context, interview *Interview) error
+	Delete(ctx context.Context, id string) error
+}
+
+// CompanyRepository has no Create: ApplicationRepository.Create and Update resolve
+// (and if needed create) the company in the same transaction as the application.
+type CompanyRepository interface {
+	GetByID(ctx context.Context, id string) (*Company, error)
+	ListSummaries(ctx context.Context, userID string) ([]*CompanySummary, error)
+	Update(ctx context.Context, company *Company) error // Wraps ErrConflict if the normalized name is taken
+	Delete(ctx context.Context, id string) error        // Wraps ErrConflict if the company still has applications
+}
+
+type ContactRepository interface {
+	Create(ctx context.Context, contact *Contact) error
+	GetByID(ctx context.Context, id string) (*Contact, error)
+	// List returns the user's contacts ordered by name. A non-empty query matches
+	// name, email, company or title, case-insensitively.
+	List(ctx context.Context, userID, query string) ([]*Contact, error)
+	Update(ctx context.Context, contact *Contact) error
+	Delete(ctx context.Context, id string) error // Also removes the contact's application links
+	// Link attaches a contact to an application, replacing the role if already linked.
+	Link(ctx context.Context, applicationID, contactID string, role ContactRole) error
+	Unlink(ctx context.Context, applicationID, contactID string) error
+	ListByApplicationID(ctx context.Context, applicationID string) ([]ApplicationContact, error)
+}
+
+type SearchRepository interface {
+	// Search returns up to limit of the user's applications matching every word of the query
+	// in their company, role, notes or history, best match first.
+	Search(ctx context.Context, userID, query string, limit int) ([]*SearchResult, error)
+}
+
+type CustomFieldRepository interface {
+	Create(ctx context.Context, field *CustomField) error // Wraps ErrConflict if the user already has a field with that key
+	GetByID(ctx context.Context, id string) (*CustomField, error)
+	ListByUserID(ctx context.Context, userID string) ([]*CustomField, error) // Oldest first
+	Update(ctx context.Context, field *CustomField) error
+	// Delete removes the field and, in the same transaction, its values from the user's applications.
+	Delete(ctx context.Context, id string) error
+}
+
+type TagRepository interface {
+	Create(ctx context.Context, tag *Tag) error // Wraps ErrConflict if the user already has a tag by that name
+	GetByID(ctx context.Context, id string) (*Tag, error)
+	ListSummaries(ctx context.Context, userID string) ([]*TagSummary, error) // Ordered by name
+	// Update renames or recolors the tag everywhere it is used. Wraps ErrConflict like Create.
+	Update(ctx context.Context, tag *Tag) error
+	Delete(ctx context.Context, id string) error // Also removes the tag from every application
+	// Merge moves every application tagged with source over to target and deletes source, atomically.
+	Merge(ctx context.Context, sourceID, targetID string) error
+	Assign(ctx context.Context, applicationID, tagID string) error // No-op if already assigned
+	Unassign(ctx context.Context, applicationID, tagID string) error
+}
+
+type OfferRepository interface {
+	Create(ctx context.Context, offer *Offer) error // Wraps ErrConflict if the application already has an offer
+	GetByID(ctx context.Context, id string) (*Offer, error)
+	GetByApplicationID(ctx context.Context, applicationID string) (*Offer, error)
+	ListByUserID(ctx context.Context, userID string) ([]*Offer, error)
+	// ListDeadlines returns the user's pending offers with a decision deadline in [from, to], soonest first.
+	ListDeadlines(ctx context.Context, userID string, from, to time.Time) ([]*Offer, error)
+	Update(ctx context.Context, offer *Offer) error
+	Delete(ctx context.Context, id string) error
+}
+
+type ReminderRepository interface {
+	Create(ctx context.Context, reminder *Reminder) error
+	GetByID(ctx context.Context, id string) (*Reminder, error)
+	ListByUserID(ctx context.Context, userID string) ([]*Reminder, error)
+	ListByApplicationID(ctx context.Context, applicationID string) ([]*Reminder, error)
+	ListDue(ctx context.Context, userID string, now time.Time) ([]*Reminder, error) // Open and due by now
+	Update(ctx context.Context, reminder *Reminder) error
+	Delete(ctx context.Context, id string) error
+	// ExistsForRule reports whether a rule already fired for this application at this anchor.
+	ExistsForRule(ctx context.Context, applicationID, ruleID, anchor string) (bool, error)
+	// ClaimUndelivered marks up to limit open, due, not yet notified reminders of any user as
+	// notified at now and returns them, so concurrent workers never deliver the same reminder twice.
+	// Reminders waiting for a retry, or that failed maxAttempts times, are skipped.
+	ClaimUndelivered(ctx context.Context, now time.Time, limit, maxAttempts int) ([]*Reminder, error)
+	// ReleaseClaim clears the notification mark after a failed delivery, counting the attempt,
+	// so it is retried from retryAt.
+	ReleaseClaim(ctx context.Context, id string, retryAt time.Time) error
+}
+
+type FollowUpRuleRepository interface {
+	Create(ctx context.Context, rule *FollowUpRule) error
+	GetByID(ctx context.Context, id string) (*FollowUpRule, error)
+	ListByUserID(ctx context.Context, userID string) ([]*FollowUpRule, error)
+	ListEnabled(ctx context.Context) ([]*FollowUpRule, error) // Across all users
+	Update(ctx context.Context, rule *FollowUpRule) error
+	Delete(ctx context.Context, id string) error
+}
+
+// CalendarTokenRepository stores at most one calendar feed token (hashed) per user.
+type CalendarTokenRepository interface {
+	Save(ctx context.Context, userID, tokenHash string) error // Replaces any existing token
+	GetUserIDByTokenHash(ctx context.Context, tokenHash string) (string, error)
+	Delete(ctx context.Context, userID string) error
+}
+
+// UserTokenRepository stores the hashes of single-use tokens emailed to users.
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *UserToken) error
+	// Consume marks the unexpired, unused token with this purpose and hash as used and
+	// returns it. Of concurrent calls for the same token, only one succeeds.
+	Consume(ctx context.Context, purpose UserTokenPurpose, tokenHash string) (*UserToken, error)
+	// InvalidateByUserID marks all of the user's unused tokens with this purpose as used.
+	InvalidateByUserID(ctx context.Context, userID string, purpose UserTokenPurpose) error
+}
+
+// RateLimitRepository counts hits per key in fixed windows, for sliding-window rate limits.
+type RateLimitRepository interface {
+	// Hit counts a hit for the key in the window starting at windowStart, and returns the
+	// hits in that window and in the one before it, which started at previousStart.
+	Hit(ctx context.Context, key string, windowStart, previousStart time.Time) (current, previous int, err error)
+	DeleteBefore(ctx context.Context, before time.Time) error // Drops windows that started earlier
+	DeleteKey(ctx context.Context, key string) error          // Drops all of the key's windows
+}
+
+// LoginAttemptRepository keeps the audit log of failed logins and the failure counts
+// lockouts are based on.
+type LoginAttemptRepository interface {
+	Record(ctx context.Context, attempt *LoginAttempt) error
+	// GetLockout returns nil without an error when the username has no recent failures.
+	GetLockout(ctx context.Context, username string) (*LoginLockout, error)
+	// AddFailure counts a failed login and returns the number of consecutive failures.
+	// Failures from before resetBefore are forgotten.
+	AddFailure(ctx context.Context, username string, at, resetBefore time.Time) (int, error)
+	Lock(ctx context.Context, username string, until time.Time) error
+	ClearFailures(ctx context.Context, username string) error
+	// Prune drops audit records created before attemptsBefore, and failure counts last
+	// updated before failuresBefore that are not locked any more.
+	Prune(ctx context.Context, attemptsBefore, failuresBefore time.Time) error
+}
+
+// SessionRepository stores login sessions. Revoked and expired sessions are kept, but never listed.
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	GetByID(ctx context.Context, id string) (*Session, error)
+	ListActiveByUserID(ctx context.Context, userID string) ([]*Session, error) // Newest first
+	// Rotate stores the session's new token hash, previous hashes, client and expiry, but only
+	// while the session is active and its token hash is still previousHash. It reports whether it did.
+	Rotate(ctx context.Context, session *Session, previousHash string) (bool, error)
+	Revoke(ctx context.Context, id string) error
+	RevokeAllByUserID(ctx context.Context, userID, exceptID string) error // exceptID may be empty
+}
+
+type WorkflowRepository interface {
+	// GetByUserID returns nil without an error when the user has not customized their workflow.
+	GetByUserID(ctx context.Context, userID string) (*Workflow, error)
+	Save(ctx context.Context, userID string, workflow *Workflow) error
+	Delete(ctx context.Context, userID string) error
 }
 
 type BlogRepository interface {
@@ -0,0 +1,119 @@
+package domain
+
+import "fmt"
+
+// DefaultWorkflow is used for users who have not defined their own.
+// Active stages can move freely; leaving Rejected or Withdrawn needs a reason,
+// and a Ghosted application can come back to life at any stage.
+func DefaultWorkflow() *Workflow {
+	active := []ApplicationStatus{StatusApplied, StatusPhoneScreen, StatusTakeHome, StatusInterviewing, StatusOnsite}
+	closed := []ApplicationStatus{StatusOffer, StatusRejected, StatusWithdrawn, StatusGhosted}
+
+	w := &Workflow{Statuses: append(append([]ApplicationStatus{}, active...), closed...)}
+	add := func(from ApplicationStatus, to []ApplicationStatus, requiresReason bool) {
+		for _, status := range to {
+			if status != from {
+				w.Transitions = append(w.Transitions, WorkflowTransition{From: from, To: status, RequiresReason: requiresReason})
+			}
+		}
+	}
+
+	for _, status := range active {
+		add(status, w.Statuses, false)
+	}
+	add(StatusOffer, []ApplicationStatus{StatusRejected, StatusWithdrawn}, false)
+	add(StatusGhosted, append(append([]ApplicationStatus{}, active...), StatusOffer, StatusRejected, StatusWithdrawn), false)
+	add(StatusRejected, append(append([]ApplicationStatus{}, active...), StatusOffer), true)
+	add(StatusWithdrawn, active, true)
+
+	return w
+}
+
+func (w *Workflow) HasStatus(status ApplicationStatus) bool {
+	for _, s := range w.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition returns the rule for moving from one status to another, if there is one.
+func (w *Workflow) Transition(from, to ApplicationStatus) (WorkflowTransition, bool) {
+	for _, t := range w.Transitions {
+		if t.From == from && t.To == to {
+			return t, true
+		}
+	}
+	return WorkflowTransition{}, false
+}
+
+// ValidateInitial checks that a new application may start in the given status.
+func (w *Workflow) ValidateInitial(status ApplicationStatus) error {
+	if !w.HasStatus(status) {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidTransition, status)
+	}
+	return nil
+}
+
+// ValidateTransition checks a status change against the workflow. Applications left in a
+// status the workflow no longer defines may move to any defined status.
+func (w *Workflow) ValidateTransition(from, to ApplicationStatus, reason string) error {
+	if from == StatusArchived {
+		return fmt.Errorf("%w: archived applications must be restored first", ErrInvalidTransition)
+	}
+	if to == StatusArchived {
+		return nil
+	}
+	if !w.HasStatus(to) {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidTransition, to)
+	}
+	if !w.HasStatus(from) {
+		return nil
+	}
+
+	transition, ok := w.Transition(from, to)
+	if !ok {
+		return fmt.Errorf("%w: cannot move from %q to %q", ErrInvalidTransition, from, to)
+	}
+	if transition.RequiresReason && reason == "" {
+		return fmt.Errorf("%w: moving from %q to %q requires a reason", ErrInvalidTransition, from, to)
+	}
+	return nil
+}
+
+// Validate checks that the workflow is well formed.
+func (w *Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return fmt.Errorf("%w: a workflow needs at least one status", ErrInvalidInput)
+	}
+
+	seen := make(map[ApplicationStatus]bool, len(w.Statuses))
+	for _, status := range w.Statuses {
+		switch {
+		case status == "" || len(status) > 50:
+			return fmt.Errorf("%w: status names must be between 1 and 50 characters", ErrInvalidInput)
+		case status == StatusArchived:
+			return fmt.Errorf("%w: %q is reserved", ErrInvalidInput, StatusArchived)
+		case seen[status]:
+			return fmt.Errorf("%w: duplicate status %q", ErrInvalidInput, status)
+		}
+		seen[status] = true
+	}
+
+	pairs := make(map[[2]ApplicationStatus]bool, len(w.Transitions))
+	for _, t := range w.Transitions {
+		pair := [2]ApplicationStatus{t.From, t.To}
+		switch {
+		case !seen[t.From] || !seen[t.To]:
+			return fmt.Errorf("%w: transition %q -> %q uses an unknown status", ErrInvalidInput, t.From, t.To)
+		case t.From == t.To:
+			return fmt.Errorf("%w: transition %q -> %q goes nowhere", ErrInvalidInput, t.From, t.To)
+		case pairs[pair]:
+			return fmt.Errorf("%w: duplicate transition %q -> %q", ErrInvalidInput, t.From, t.To)
+		}
+		pairs[pair] = true
+	}
+
+	return nil
+}
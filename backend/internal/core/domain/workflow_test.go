@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWorkflowValidateTransition(t *testing.T) {
+	custom := &Workflow{
+		Statuses: []ApplicationStatus{"Sourced", StatusApplied, StatusRejected},
+		Transitions: []WorkflowTransition{
+			{From: "Sourced", To: StatusApplied},
+			{From: StatusApplied, To: StatusRejected},
+			{From: StatusRejected, To: StatusApplied, RequiresReason: true},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		workflow *Workflow
+		from, to ApplicationStatus
+		reason   string
+		wantErr  bool
+	}{
+		{"default active to active", DefaultWorkflow(), StatusApplied, StatusInterviewing, "", false},
+		{"default active to closed", DefaultWorkflow(), StatusOnsite, StatusRejected, "", false},
+		{"default ghosted comes back", DefaultWorkflow(), StatusGhosted, StatusInterviewing, "", false},
+		{"default offer back to applied", DefaultWorkflow(), StatusOffer, StatusApplied, "", true},
+		{"default reopen rejected without reason", DefaultWorkflow(), StatusRejected, StatusApplied, "", true},
+		{"default reopen rejected with reason", DefaultWorkflow(), StatusRejected, StatusApplied, "They called back", false},
+		{"default withdrawn to offer", DefaultWorkflow(), StatusWithdrawn, StatusOffer, "Changed my mind", true},
+		{"archive from anywhere", DefaultWorkflow(), StatusOffer, StatusArchived, "", false},
+		{"archived must be restored first", DefaultWorkflow(), StatusArchived, StatusApplied, "", true},
+		{"custom allowed", custom, "Sourced", StatusApplied, "", false},
+		{"custom not listed", custom, "Sourced", StatusRejected, "", true},
+		{"custom requires reason", custom, StatusRejected, StatusApplied, "", true},
+		{"custom reason given", custom, StatusRejected, StatusApplied, "Reopened", false},
+		{"unknown target", custom, StatusApplied, StatusInterviewing, "", true},
+		{"status dropped from workflow may move anywhere", custom, StatusInterviewing, "Sourced", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.workflow.ValidateTransition(tt.from, tt.to, tt.reason)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateTransition(%q, %q, %q) = %v, want error: %v", tt.from, tt.to, tt.reason, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidTransition) {
+				t.Errorf("error %v does not wrap ErrInvalidTransition", err)
+			}
+		})
+	}
+}
+
+func TestWorkflowValidateInitial(t *testing.T) {
+	tests := []struct {
+		status  ApplicationStatus
+		wantErr bool
+	}{
+		{StatusApplied, false},
+		{StatusOffer, false},
+		{StatusArchived, true},
+		{"Bogus", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		err := DefaultWorkflow().ValidateInitial(tt.status)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateInitial(%q) = %v, want error: %v", tt.status, err, tt.wantErr)
+		}
+	}
+}
+
+func TestWorkflowValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow *Workflow
+		wantErr  bool
+	}{
+		{"default", DefaultWorkflow(), false},
+		{"no statuses", &Workflow{}, true},
+		{"archived is reserved", &Workflow{Statuses: []ApplicationStatus{StatusApplied, StatusArchived}}, true},
+		{"duplicate status", &Workflow{Statuses: []ApplicationStatus{StatusApplied, StatusApplied}}, true},
+		{"transition to unknown status", &Workflow{
+			Statuses:    []ApplicationStatus{StatusApplied},
+			Transitions: []WorkflowTransition{{From: StatusApplied, To: StatusOffer}},
+		}, true},
+		{"transition to itself", &Workflow{
+			Statuses:    []ApplicationStatus{StatusApplied},
+			Transitions: []WorkflowTransition{{From: StatusApplied, To: StatusApplied}},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.workflow.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() = %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
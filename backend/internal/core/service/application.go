@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"joblog/internal/core/domain"
@@ -12,27 +15,38 @@ import (
 )
 
 type ApplicationService struct {
-	repo domain.ApplicationRepository
+	repo         domain.ApplicationRepository
+	workflows    *WorkflowService
+	contacts     domain.ContactRepository
+	customFields domain.CustomFieldRepository
+	tags         domain.TagRepository
+	duplicates   DuplicatePolicy
 }
 
-func NewApplicationService(repo domain.ApplicationRepository) *ApplicationService {
-	return &ApplicationService{repo: repo}
+func NewApplicationService(repo domain.ApplicationRepository, workflows *WorkflowService, contacts domain.ContactRepository, customFields domain.CustomFieldRepository, tags domain.TagRepository, duplicates DuplicatePolicy) *ApplicationService {
+	return &ApplicationService{repo: repo, workflows: workflows, contacts: contacts, customFields: customFields, tags: tags, duplicates: duplicates}
 }
 
-func (s *ApplicationService) Create(ctx context.Context, userID string, newApp domain.NewApplication) (*domain.Application, error) {
-	now := time.Now().Format("2006-01-02")
-	app := &domain.Application{
-		ID:        uuid.NewString(),
-		UserID:    userID,
-		Company:   newApp.Company,
-		Role:      newApp.Role,
-		Date:      newApp.Date,
-		UpdatedAt: now,
-		Status:    newApp.Status,
-		Notes:     []domain.Note{},
-		History: []domain.HistoryEvent{
-			{Date: time.Now(), Event: fmt.Sprintf("Application created with status: %s", newApp.Status)},
-		},
+// Create adds an application. Unless force is set, it refuses with a *domain.DuplicateError
+// when the user already has what looks like the same application.
+func (s *ApplicationService) Create(ctx context.Context, userID string, newApp domain.NewApplication, force bool) (*domain.Application, error) {
+	workflow, err := s.workflows.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	app, err := s.newApplication(ctx, userID, workflow, newApp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !force {
+		ids, err := s.findDuplicates(ctx, userID, app, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) > 0 {
+			return nil, &domain.DuplicateError{IDs: ids}
+		}
 	}
 
 	if err := s.repo.Create(ctx, app); err != nil {
@@ -41,52 +55,258 @@ func (s *ApplicationService) Create(ctx context.Context, userID string, newApp d
 	return app, nil
 }
 
-func (s *ApplicationService) GetAllByUserID(ctx context.Context, userID string) ([]*domain.Application, error) {
-	return s.repo.GetAllByUserID(ctx, userID)
+// newApplication validates newApp against the user's workflow and builds the application
+// to store, without saving it.
+func (s *ApplicationService) newApplication(ctx context.Context, userID string, workflow *domain.Workflow, newApp domain.NewApplication) (*domain.Application, error) {
+	// Applications are grouped into companies by name, so an empty one can't be allowed
+	if strings.TrimSpace(newApp.Company) == "" {
+		return nil, fmt.Errorf("%w: company is required", domain.ErrInvalidInput)
+	}
+	if _, err := time.Parse("2006-01-02", newApp.Date); err != nil {
+		return nil, fmt.Errorf("%w: date %q must be in YYYY-MM-DD format", domain.ErrInvalidInput, newApp.Date)
+	}
+	posting := newApp.JobPosting
+	normalizeJobPosting(&posting)
+	if err := validateJobPosting(&posting); err != nil {
+		return nil, err
+	}
+	if err := workflow.ValidateInitial(newApp.Status); err != nil {
+		return nil, err
+	}
+	customFields := domain.CustomFieldValues{}
+	if len(newApp.CustomFields) > 0 {
+		fields, err := s.customFields.ListByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := applyCustomFields(fields, customFields, newApp.CustomFields); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().Format("2006-01-02")
+	return &domain.Application{
+		ID:           uuid.NewString(),
+		UserID:       userID,
+		Company:      newApp.Company,
+		Role:         newApp.Role,
+		Date:         newApp.Date,
+		UpdatedAt:    now,
+		Status:       newApp.Status,
+		JobPosting:   posting,
+		CustomFields: customFields,
+		Tags:         []domain.Tag{},
+		Notes:        []domain.Note{},
+		History:      []domain.HistoryEvent{createdEvent(newApp.Status)},
+	}, nil
+}
+
+// findDuplicates returns the IDs of the user's applications that app looks like a duplicate of.
+// The pending applications, not yet stored, are checked as well.
+func (s *ApplicationService) findDuplicates(ctx context.Context, userID string, app *domain.Application, pending []*domain.Application) ([]string, error) {
+	candidates, err := s.repo.FindByCompanyName(ctx, userID, domain.NormalizeCompanyName(app.Company))
+	if err != nil {
+		return nil, err
+	}
+	return s.matchDuplicates(app, candidates, pending), nil
+}
+
+// matchDuplicates is findDuplicates with the stored applications to the company already loaded.
+func (s *ApplicationService) matchDuplicates(app *domain.Application, stored, pending []*domain.Application) []string {
+	normalized := domain.NormalizeCompanyName(app.Company)
+	candidates := slices.Clone(stored)
+	for _, other := range pending {
+		if domain.NormalizeCompanyName(other.Company) == normalized {
+			candidates = append(candidates, other)
+		}
+	}
+	date, _ := time.Parse("2006-01-02", app.Date)
+	return s.duplicates.findDuplicates(app.Role, date, candidates)
+}
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// List validates the filter, fills in defaults and returns one page of applications.
+func (s *ApplicationService) List(ctx context.Context, userID string, filter domain.ApplicationFilter) (*domain.ApplicationPage, error) {
+	filter, err := s.normalizeFilter(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, userID, filter)
+}
+
+// normalizeFilter validates a list filter and fills in its defaults.
+func (s *ApplicationService) normalizeFilter(ctx context.Context, userID string, filter domain.ApplicationFilter) (domain.ApplicationFilter, error) {
+	switch filter.Sort {
+	case "":
+		filter.Sort = domain.SortByDate
+		filter.Descending = true
+	case domain.SortByDate, domain.SortByUpdatedAt, domain.SortByCompany:
+	default:
+		return filter, fmt.Errorf("%w: unknown sort field %q", domain.ErrInvalidInput, filter.Sort)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+
+	for _, date := range []string{filter.DateFrom, filter.DateTo, filter.UpdatedBefore} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return filter, fmt.Errorf("%w: date %q must be in YYYY-MM-DD format", domain.ErrInvalidInput, date)
+		}
+	}
+
+	for _, status := range filter.Statuses {
+		if status == domain.StatusArchived {
+			filter.IncludeArchived = true
+		}
+	}
+
+	if filter.Cursor != nil && (filter.Cursor.Sort != filter.Sort || filter.Cursor.Descending != filter.Descending) {
+		return filter, fmt.Errorf("%w: cursor does not match the requested sort", domain.ErrInvalidInput)
+	}
+
+	if len(filter.CustomFields) > 0 {
+		fields, err := s.customFields.ListByUserID(ctx, userID)
+		if err != nil {
+			return filter, err
+		}
+		if filter.CustomFields, err = parseCustomFieldFilter(fields, filter.CustomFields); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+func (s *ApplicationService) GetStats(ctx context.Context, userID string) (*domain.ApplicationStats, error) {
+	return s.repo.GetStatsByUserID(ctx, userID)
 }
 
 func (s *ApplicationService) GetByID(ctx context.Context, userID, appID string) (*domain.Application, error) {
 	app, err := s.repo.GetByID(ctx, appID)
 	if err != nil {
-		log.Println("[ApplicationService.GetByID] Error: ", err)
-		return nil, fmt.Errorf("application not found")
+		return nil, err
 	}
+	// Someone else's application is reported as missing, so IDs can't be probed
 	if app.UserID != userID {
-		return nil, fmt.Errorf("access denied")
+		return nil, fmt.Errorf("application %w", domain.ErrNotFound)
 	}
 	return app, nil
 }
 
-func (s *ApplicationService) Update(ctx context.Context, userID, appID string, updateData domain.ApplicationUpdate) (*domain.Application, error) {
+// GetDetail returns an application together with its linked contacts.
+func (s *ApplicationService) GetDetail(ctx context.Context, userID, appID string) (*domain.Application, error) {
 	app, err := s.GetByID(ctx, userID, appID)
 	if err != nil {
 		return nil, err
 	}
+	contacts, err := s.contacts.ListByApplicationID(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Memory repositories hand out shared pointers, so don't decorate the stored application
+	detail := *app
+	detail.Contacts = contacts
+	return &detail, nil
+}
+
+func (s *ApplicationService) Update(ctx context.Context, userID, appID string, updateData domain.ApplicationUpdate) (*domain.Application, error) {
+	current, err := s.GetByID(ctx, userID, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateData.Company != nil && strings.TrimSpace(*updateData.Company) == "" {
+		return nil, fmt.Errorf("%w: company is required", domain.ErrInvalidInput)
+	}
+	if updateData.Date != nil {
+		if _, err := time.Parse("2006-01-02", *updateData.Date); err != nil {
+			return nil, fmt.Errorf("%w: date %q must be in YYYY-MM-DD format", domain.ErrInvalidInput, *updateData.Date)
+		}
+	}
 
-	// Apply updates
-	if updateData.Company != nil {
+	// Validate the status change before touching anything
+	statusChanged := updateData.Status != nil && current.Status != *updateData.Status
+	var reason string
+	if updateData.StatusReason != nil {
+		reason = strings.TrimSpace(*updateData.StatusReason)
+	}
+	if statusChanged {
+		if err := s.workflows.ValidateTransition(ctx, userID, current.Status, *updateData.Status, reason); err != nil {
+			return nil, err
+		}
+	}
+
+	// Work on a copy so a failed validation leaves the stored application untouched
+	app := *current
+	applyJobPostingUpdate(&app.JobPosting, updateData)
+	normalizeJobPosting(&app.JobPosting)
+	if err := validateJobPosting(&app.JobPosting); err != nil {
+		return nil, err
+	}
+	var customFieldsChanged []string
+	if len(updateData.CustomFields) > 0 {
+		fields, err := s.customFields.ListByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		// The map is shared with the stored application, so change a copy
+		app.CustomFields = maps.Clone(current.CustomFields)
+		if app.CustomFields == nil {
+			app.CustomFields = domain.CustomFieldValues{}
+		}
+		if customFieldsChanged, err = applyCustomFields(fields, app.CustomFields, updateData.CustomFields); err != nil {
+			return nil, err
+		}
+	}
+
+	// Apply updates, keeping track of which fields actually changed
+	var edited []string
+	if updateData.Company != nil && app.Company != *updateData.Company {
 		app.Company = *updateData.Company
+		edited = append(edited, "company")
 	}
-	if updateData.Role != nil {
+	if updateData.Role != nil && app.Role != *updateData.Role {
 		app.Role = *updateData.Role
+		edited = append(edited, "role")
 	}
-	if updateData.Date != nil {
+	if updateData.Date != nil && app.Date != *updateData.Date {
 		app.Date = *updateData.Date
+		edited = append(edited, "date")
+	}
+	edited = append(edited, jobPostingChanges(&current.JobPosting, &app.JobPosting)...)
+	for _, key := range customFieldsChanged {
+		edited = append(edited, "customFields."+key)
+	}
+	if len(edited) > 0 {
+		app.History = append(app.History, fieldsEditedEvent(edited))
 	}
-	if updateData.Status != nil && app.Status != *updateData.Status {
+	if statusChanged {
+		event := statusChangedEvent(app.Status, *updateData.Status)
+		if *updateData.Status == domain.StatusArchived {
+			event = archivedEvent(app.Status)
+		}
+		event.Reason = reason
+		app.History = append(app.History, event)
 		app.Status = *updateData.Status
-		app.History = append(app.History, domain.HistoryEvent{
-			Date:  time.Now(),
-			Event: fmt.Sprintf("Status updated to: %s", *updateData.Status),
-		})
 	}
 
 	app.UpdatedAt = time.Now().Format("2006-01-02")
 
-	if err := s.repo.Update(ctx, app); err != nil {
+	if err := s.repo.Update(ctx, &app); err != nil {
 		return nil, err
 	}
-	return app, nil
+	return &app, nil
 }
 
 func (s *ApplicationService) Archive(ctx context.Context, userID, appID string) error {
@@ -95,12 +315,64 @@ func (s *ApplicationService) Archive(ctx context.Context, userID, appID string)
 		return err
 	}
 
+	if app.Status == domain.StatusArchived {
+		return nil
+	}
+
+	app.History = append(app.History, archivedEvent(app.Status))
 	app.Status = domain.StatusArchived
 	app.UpdatedAt = time.Now().Format("2006-01-02")
-	app.History = append(app.History, domain.HistoryEvent{
-		Date:  time.Now(),
-		Event: "Application archived",
-	})
+
+	return s.repo.Update(ctx, app)
+}
+
+// Delete permanently removes an application, its notes and its history.
+func (s *ApplicationService) Delete(ctx context.Context, userID, appID string) error {
+	if _, err := s.GetByID(ctx, userID, appID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, appID)
+}
+
+// Restore un-archives an application, putting back the status it had before it was archived.
+func (s *ApplicationService) Restore(ctx context.Context, userID, appID string) (*domain.Application, error) {
+	app, err := s.GetByID(ctx, userID, appID)
+	if err != nil {
+		return nil, err
+	}
+	if app.Status != domain.StatusArchived {
+		return nil, fmt.Errorf("%w: application is not archived", domain.ErrConflict)
+	}
+
+	status := statusBeforeArchive(app.History)
+	app.Status = status
+	app.UpdatedAt = time.Now().Format("2006-01-02")
+	app.History = append(app.History, restoredEvent(status))
+
+	if err := s.repo.Update(ctx, app); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// advanceForInterview moves an Applied application to Interviewing when its first
+// interview is scheduled. Workflows that do not allow the move are left alone.
+func (s *ApplicationService) advanceForInterview(ctx context.Context, userID string, app *domain.Application, interview *domain.Interview) error {
+	if app.Status != domain.StatusApplied {
+		return nil
+	}
+	if err := s.workflows.ValidateTransition(ctx, userID, app.Status, domain.StatusInterviewing, ""); err != nil {
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			return nil
+		}
+		return err
+	}
+
+	event := statusChangedEvent(app.Status, domain.StatusInterviewing)
+	event.Reason = "Interview scheduled: " + interview.RoundName
+	app.History = append(app.History, event)
+	app.Status = domain.StatusInterviewing
+	app.UpdatedAt = time.Now().Format("2006-01-02")
 
 	return s.repo.Update(ctx, app)
 }
@@ -117,6 +389,7 @@ func (s *ApplicationService) AddNote(ctx context.Context, userID, appID, content
 		CreatedAt: time.Now(),
 	}
 	app.Notes = append(app.Notes, newNote)
+	app.History = append(app.History, noteAddedEvent())
 	app.UpdatedAt = time.Now().Format("2006-01-02")
 
 	if err := s.repo.Update(ctx, app); err != nil {
@@ -142,7 +415,7 @@ func (s *ApplicationService) UpdateNote(ctx context.Context, userID, appID, note
 	}
 
 	if targetNote == nil {
-		return nil, fmt.Errorf("note not found")
+		return nil, fmt.Errorf("note %w", domain.ErrNotFound)
 	}
 
 	app.UpdatedAt = time.Now().Format("2006-01-02")
@@ -168,7 +441,7 @@ func (s *ApplicationService) DeleteNote(ctx context.Context, userID, appID, note
 	}
 
 	if noteIndex == -1 {
-		return fmt.Errorf("note not found")
+		return fmt.Errorf("note %w", domain.ErrNotFound)
 	}
 
 	// Remove the note from the slice
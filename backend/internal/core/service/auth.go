@@ -5,31 +5,87 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	netmail "net/mail"
+	"slices"
+	"strings"
+	"time"
 
 	"joblog/internal/core/domain"
 	"joblog/pkg/auth"
+	"joblog/pkg/mail"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// sessionDuration is how long a session lasts without being refreshed. Every refresh
+// extends it, so a device that is used at least once a month stays logged in.
+const sessionDuration = 30 * 24 * time.Hour
+
+const (
+	// refreshGracePeriod is how long the refresh token a session just replaced still works,
+	// for clients that sent it twice at the same time.
+	refreshGracePeriod = 30 * time.Second
+	// maxPreviousTokenHashes is how many replaced refresh tokens a session remembers. Replaying
+	// an older one is rejected without revoking the session.
+	maxPreviousTokenHashes = 20
+)
+
+// How long the links in verification and password reset emails work.
+const (
+	verifyEmailTokenDuration   = 48 * time.Hour
+	resetPasswordTokenDuration = time.Hour
+)
+
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72 // Bytes; bcrypt can't hash anything longer
+)
+
+var errInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+// dummyPasswordHash is checked when logging in as a username that doesn't exist, so that
+// takes as long as a wrong password and the response time doesn't tell which usernames
+// are taken. It has bcrypt.DefaultCost like real hashes.
+const dummyPasswordHash = "$2a$10$9qKHxIv71aXoIGtzXxfVCOfemz5f8IeKU9nsfARTG37pBoYCnfNHC"
+
 type AuthService struct {
-	userRepo   domain.UserRepository
-	jwtManager *auth.JWTManager
+	userRepo    domain.UserRepository
+	sessionRepo domain.SessionRepository
+	tokenRepo   domain.UserTokenRepository
+	loginGuard  *LoginGuard
+	jwtManager  *auth.JWTManager
+	mailer      mail.Sender
+	appURL      string // Base URL of the web app, for links in emails
 }
 
-func NewAuthService(userRepo domain.UserRepository, jwtManager *auth.JWTManager) *AuthService {
+func NewAuthService(userRepo domain.UserRepository, sessionRepo domain.SessionRepository, tokenRepo domain.UserTokenRepository, loginGuard *LoginGuard, jwtManager *auth.JWTManager, mailer mail.Sender, appURL string) *AuthService {
 	return &AuthService{
-		userRepo:   userRepo,
-		jwtManager: jwtManager,
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		tokenRepo:   tokenRepo,
+		loginGuard:  loginGuard,
+		jwtManager:  jwtManager,
+		mailer:      mailer,
+		appURL:      strings.TrimSuffix(appURL, "/"),
 	}
 }
 
 func (s *AuthService) Register(ctx context.Context, reg domain.UserRegistration) (*domain.User, error) {
 	// Basic validation
+	reg.Username, reg.Email = strings.TrimSpace(reg.Username), strings.TrimSpace(reg.Email)
 	if reg.Username == "" || reg.Email == "" || reg.Password == "" {
 		return nil, errors.New("username, email, and password are required")
 	}
+	if err := validateUsername(reg.Username); err != nil {
+		return nil, err
+	}
+	if err := validateEmail(reg.Email); err != nil {
+		return nil, err
+	}
+	if err := validatePassword(reg.Password); err != nil {
+		return nil, err
+	}
 
 	// Check if user already exists
 	if _, err := s.userRepo.GetByEmail(ctx, reg.Email); err == nil {
@@ -59,33 +115,542 @@ func (s *AuthService) Register(ctx context.Context, reg domain.UserRegistration)
 		return nil, fmt.Errorf("could not create user: %w", err)
 	}
 
+	// The account works without a verified email, so a failure here can be fixed with a resend
+	if err := s.sendVerificationEmail(ctx, user); err != nil {
+		log.Println("[Register] Error: ", err)
+	}
+
 	return user, nil
 }
 
-func (s *AuthService) Login(ctx context.Context, login domain.UserLogin) (*domain.AuthResponse, error) {
+// ResendVerificationEmail sends a new verification link, invalidating earlier ones. While
+// an email change is pending, the link confirming the new address is sent instead.
+func (s *AuthService) ResendVerificationEmail(ctx context.Context, userID string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.PendingEmail != "" {
+		if err := s.tokenRepo.InvalidateByUserID(ctx, userID, domain.PurposeChangeEmail); err != nil {
+			return err
+		}
+		return s.sendChangeEmailConfirmation(ctx, user)
+	}
+	if user.EmailVerified {
+		return fmt.Errorf("%w: email is already verified", domain.ErrConflict)
+	}
+	if err := s.tokenRepo.InvalidateByUserID(ctx, userID, domain.PurposeVerifyEmail); err != nil {
+		return err
+	}
+	return s.sendVerificationEmail(ctx, user)
+}
+
+func (s *AuthService) sendVerificationEmail(ctx context.Context, user *domain.User) error {
+	token, err := s.issueToken(ctx, user.ID, domain.PurposeVerifyEmail, verifyEmailTokenDuration)
+	if err != nil {
+		return err
+	}
+	s.send(mail.Message{
+		To:      user.Email,
+		Subject: "Confirm your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address for JobLog by opening this link:\n\n%s/verify-email?token=%s\n\n"+
+			"The link expires in %d hours. If you didn't create an account, you can ignore this email.\n",
+			user.Username, s.appURL, token, int(verifyEmailTokenDuration.Hours())),
+	})
+	return nil
+}
+
+func (s *AuthService) sendChangeEmailConfirmation(ctx context.Context, user *domain.User) error {
+	token, err := s.issueToken(ctx, user.ID, domain.PurposeChangeEmail, verifyEmailTokenDuration)
+	if err != nil {
+		return err
+	}
+	s.send(mail.Message{
+		To:      user.PendingEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf("Hi %s,\n\nTo start using this address for your JobLog account, open this link:\n\n%s/verify-email?token=%s\n\n"+
+			"Until then, your account keeps using %s. The link expires in %d hours. If you didn't ask for this, you can ignore this email.\n",
+			user.Username, s.appURL, token, user.Email, int(verifyEmailTokenDuration.Hours())),
+	})
+	return nil
+}
+
+// VerifyEmail marks the email address a verification token was sent to as verified. For
+// a token sent to a pending email, that address becomes the account's email.
+func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	hash := auth.HashToken(token)
+	consumed, err := s.tokenRepo.Consume(ctx, domain.PurposeVerifyEmail, hash)
+	if err == nil {
+		return s.userRepo.MarkEmailVerified(ctx, consumed.UserID)
+	}
+	consumed, err = s.tokenRepo.Consume(ctx, domain.PurposeChangeEmail, hash)
+	if err != nil {
+		log.Println("[VerifyEmail] Error: ", err)
+		return fmt.Errorf("%w: invalid or expired verification link", domain.ErrInvalidInput)
+	}
+	return s.confirmEmailChange(ctx, consumed.UserID)
+}
+
+// confirmEmailChange swaps in the user's pending email. Links sent to the old address stop
+// working, and the old address is told about the change.
+func (s *AuthService) confirmEmailChange(ctx context.Context, userID string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.PendingEmail == "" {
+		return fmt.Errorf("%w: invalid or expired verification link", domain.ErrInvalidInput)
+	}
+	oldEmail := user.Email
+	updated := *user
+	updated.Email = user.PendingEmail
+	updated.PendingEmail = ""
+	updated.EmailVerified = true
+	if err := s.userRepo.Update(ctx, &updated); err != nil {
+		return err
+	}
+
+	for _, purpose := range []domain.UserTokenPurpose{domain.PurposeVerifyEmail, domain.PurposeResetPassword} {
+		if err := s.tokenRepo.InvalidateByUserID(ctx, userID, purpose); err != nil {
+			return err
+		}
+	}
+	s.send(mail.Message{
+		To:      oldEmail,
+		Subject: "Your email address was changed",
+		Body: fmt.Sprintf("Hi %s,\n\nThe email address of your JobLog account was changed to %s. "+
+			"If you didn't do this, contact us right away.\n", updated.Username, updated.Email),
+	})
+	return nil
+}
+
+// ForgotPassword emails a password reset link to the account with this email, if there
+// is one. The outcome is the same either way, so callers can't probe for accounts.
+func (s *AuthService) ForgotPassword(ctx context.Context, email string) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return fmt.Errorf("%w: email is required", domain.ErrInvalidInput)
+	}
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		log.Println("[ForgotPassword] Error: ", err)
+		return nil
+	}
+
+	// Only the latest link works
+	if err := s.tokenRepo.InvalidateByUserID(ctx, user.ID, domain.PurposeResetPassword); err != nil {
+		return err
+	}
+	token, err := s.issueToken(ctx, user.ID, domain.PurposeResetPassword, resetPasswordTokenDuration)
+	if err != nil {
+		return err
+	}
+	s.send(mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nSomeone asked to reset the password of your JobLog account. To choose a new one, open this link:\n\n"+
+			"%s/reset-password?token=%s\n\nThe link expires in %d minutes and can be used once. If it wasn't you, you can ignore this email.\n",
+			user.Username, s.appURL, token, int(resetPasswordTokenDuration.Minutes())),
+	})
+	return nil
+}
+
+// ResetPassword sets a new password using a token from a reset email, and logs the user
+// out everywhere in case the old password was compromised. Having received the email
+// also proves the address, so it is marked as verified.
+func (s *AuthService) ResetPassword(ctx context.Context, token, password string) error {
+	if err := validatePassword(password); err != nil {
+		return err
+	}
+	// Hash before consuming the single-use token, so a hashing failure doesn't use up the link
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("could not hash password: %w", err)
+	}
+	consumed, err := s.tokenRepo.Consume(ctx, domain.PurposeResetPassword, auth.HashToken(token))
+	if err != nil {
+		log.Println("[ResetPassword] Error: ", err)
+		return fmt.Errorf("%w: invalid or expired reset link", domain.ErrInvalidInput)
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, consumed.UserID, string(hashedPassword)); err != nil {
+		return err
+	}
+	if err := s.sessionRepo.RevokeAllByUserID(ctx, consumed.UserID, ""); err != nil {
+		return err
+	}
+	if err := s.tokenRepo.InvalidateByUserID(ctx, consumed.UserID, domain.PurposeResetPassword); err != nil {
+		return err
+	}
+	return s.userRepo.MarkEmailVerified(ctx, consumed.UserID)
+}
+
+func validatePassword(password string) error {
+	if len(password) < minPasswordLength {
+		return fmt.Errorf("%w: password must be at least %d characters long", domain.ErrInvalidInput, minPasswordLength)
+	}
+	if len(password) > maxPasswordLength {
+		return fmt.Errorf("%w: password must be at most %d bytes long", domain.ErrInvalidInput, maxPasswordLength)
+	}
+	return nil
+}
+
+// issueToken stores a new single-use token for the user and returns it.
+func (s *AuthService) issueToken(ctx context.Context, userID string, purpose domain.UserTokenPurpose, ttl time.Duration) (string, error) {
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	err = s.tokenRepo.Create(ctx, &domain.UserToken{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: auth.HashToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// send delivers the email in the background, so slow mail servers don't hold up requests
+// and response times don't reveal whether an email was sent.
+func (s *AuthService) send(msg mail.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := s.mailer.Send(ctx, msg); err != nil {
+			log.Printf("[AuthService.send] Error sending %q to %s: %v", msg.Subject, msg.To, err)
+		}
+	}()
+}
+
+// Login checks the user's credentials and starts a new session for the client.
+// While the username is locked out after too many failures, it returns a
+// *domain.RateLimitError without checking the password.
+func (s *AuthService) Login(ctx context.Context, login domain.UserLogin, client domain.SessionClient) (*domain.AuthResponse, error) {
+	username := NormalizeUsername(login.Username)
+	if err := s.loginGuard.CheckLockout(ctx, username); err != nil {
+		if errors.Is(err, domain.ErrRateLimited) {
+			s.recordLoginFailure(ctx, username, "", domain.LoginLockedOut, client)
+		}
+		return nil, err
+	}
+
 	user, err := s.userRepo.GetByUsername(ctx, login.Username)
 	if err != nil {
 		log.Println("[Login] Error: ", err)
+		bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(login.Password))
+		s.recordLoginFailure(ctx, username, "", domain.LoginUnknownUser, client)
 		return nil, errors.New("invalid username or password")
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(login.Password))
 	if err != nil {
 		log.Println("[Login] Error: ", err)
+		s.recordLoginFailure(ctx, username, user.ID, domain.LoginWrongPassword, client)
 		return nil, errors.New("invalid username or password")
 	}
+	if err := s.loginGuard.RecordSuccess(ctx, username); err != nil {
+		log.Println("[Login] Error: ", err)
+	}
+
+	secret, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	session := &domain.Session{
+		ID:         uuid.NewString(),
+		UserID:     user.ID,
+		TokenHash:  auth.HashToken(secret),
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(sessionDuration),
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return s.authResponse(user, session, secret)
+}
+
+// recordLoginFailure audits a failed login. The caller fails the login either way, so
+// errors are only logged.
+func (s *AuthService) recordLoginFailure(ctx context.Context, username, userID string, reason domain.LoginFailureReason, client domain.SessionClient) {
+	if err := s.loginGuard.RecordFailure(ctx, username, userID, reason, client); err != nil {
+		log.Println("[Login] Error recording failure: ", err)
+	}
+}
 
-	token, err := s.jwtManager.Generate(user)
+// authResponse issues an access token for the session, alongside its refresh token.
+func (s *AuthService) authResponse(user *domain.User, session *domain.Session, secret string) (*domain.AuthResponse, error) {
+	token, expiresAt, err := s.jwtManager.Generate(user, session.ID)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate token: %w", err)
 	}
 
 	return &domain.AuthResponse{
-		Token: token,
-		User:  user,
+		Token:          token,
+		TokenExpiresAt: expiresAt,
+		RefreshToken:   session.ID + "." + secret,
+		User:           user,
 	}, nil
 }
 
+// Refresh exchanges a refresh token for a new access token and a new refresh token. Each
+// refresh token works once: presenting one that was already used means it was copied, so
+// the session is revoked, logging out both the legitimate client and whoever copied it.
+// The token replaced last keeps working for refreshGracePeriod, so two tabs refreshing
+// at the same time don't trip this. A secret the session never had is simply rejected.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string, client domain.SessionClient) (*domain.AuthResponse, error) {
+	sessionID, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || uuid.Validate(sessionID) != nil {
+		return nil, errInvalidRefreshToken
+	}
+	hash := auth.HashToken(secret)
+
+	// Rotation only succeeds if nobody rotated the session in between; on losing that
+	// race, the session is loaded again and the token checked against the new state
+	for attempt := 0; attempt < 2; attempt++ {
+		session, err := s.sessionRepo.GetByID(ctx, sessionID)
+		if err != nil {
+			log.Println("[Refresh] Error: ", err)
+			return nil, errInvalidRefreshToken
+		}
+		now := time.Now()
+		if session.RevokedAt != nil || !session.ExpiresAt.After(now) {
+			return nil, errInvalidRefreshToken
+		}
+
+		switch i := slices.Index(session.PreviousTokenHashes, hash); {
+		case hash == session.TokenHash:
+		case i == 0 && session.RotatedAt != nil && now.Sub(*session.RotatedAt) < refreshGracePeriod:
+			log.Printf("[Refresh] Session %s refreshed with the token it just replaced; within the grace period", session.ID)
+		case i >= 0:
+			return nil, s.revokeReused(ctx, session)
+		default:
+			return nil, errInvalidRefreshToken
+		}
+
+		newSecret, err := auth.GenerateOpaqueToken()
+		if err != nil {
+			return nil, err
+		}
+		previousHash := session.TokenHash
+		rotated := *session
+		rotated.TokenHash = auth.HashToken(newSecret)
+		rotated.PreviousTokenHashes = append([]string{previousHash}, session.PreviousTokenHashes...)
+		if len(rotated.PreviousTokenHashes) > maxPreviousTokenHashes {
+			rotated.PreviousTokenHashes = rotated.PreviousTokenHashes[:maxPreviousTokenHashes]
+		}
+		rotated.RotatedAt = &now
+		rotated.UserAgent = client.UserAgent
+		rotated.IP = client.IP
+		rotated.LastUsedAt = now
+		rotated.ExpiresAt = now.Add(sessionDuration)
+		ok, err := s.sessionRepo.Rotate(ctx, &rotated, previousHash)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		user, err := s.userRepo.GetByID(ctx, session.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return s.authResponse(user, &rotated, newSecret)
+	}
+	return nil, errInvalidRefreshToken
+}
+
+func (s *AuthService) revokeReused(ctx context.Context, session *domain.Session) error {
+	log.Printf("[Refresh] Refresh token reuse detected, revoking session %s of user %s", session.ID, session.UserID)
+	if err := s.sessionRepo.Revoke(ctx, session.ID); err != nil {
+		return err
+	}
+	return errInvalidRefreshToken
+}
+
+// Logout revokes the session the request was made from. Its access tokens stop working
+// immediately and its refresh token can no longer be used.
+func (s *AuthService) Logout(ctx context.Context, userID, sessionID string) error {
+	return s.RevokeSession(ctx, userID, sessionID)
+}
+
+// ListSessions returns the user's active sessions, flagging the one the request came from.
+func (s *AuthService) ListSessions(ctx context.Context, userID, currentSessionID string) ([]*domain.Session, error) {
+	sessions, err := s.sessionRepo.ListActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		session.Current = session.ID == currentSessionID
+	}
+	return sessions, nil
+}
+
+// RevokeSession logs one of the user's devices out.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return fmt.Errorf("session %w", domain.ErrNotFound)
+	}
+	return s.sessionRepo.Revoke(ctx, session.ID)
+}
+
+// SessionActive reports whether the user's session has neither been revoked nor expired.
+// The authentication middleware checks it on every request, so a revoked session's
+// access tokens stop working before they expire.
+func (s *AuthService) SessionActive(ctx context.Context, userID, sessionID string) (bool, error) {
+	if uuid.Validate(sessionID) != nil {
+		return false, nil
+	}
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return false, err
+	}
+	return session.UserID == userID && session.RevokedAt == nil && session.ExpiresAt.After(time.Now()), nil
+}
+
 func (s *AuthService) GetUserProfile(ctx context.Context, userID string) (*domain.User, error) {
 	return s.userRepo.GetByID(ctx, userID)
 }
+
+// UpdateProfile changes the user's username and/or email. The username changes at once. A
+// new email needs the current password and is only pending until the link sent to it is
+// opened, so a stolen access token can't move the account to an attacker's address.
+// Asking for the current email again cancels a pending change.
+func (s *AuthService) UpdateProfile(ctx context.Context, userID string, req domain.UpdateProfileRequest) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	updated := *user
+
+	if req.Username != nil {
+		updated.Username = strings.TrimSpace(*req.Username)
+		if err := validateUsername(updated.Username); err != nil {
+			return nil, err
+		}
+	}
+	if req.Email != nil {
+		email := strings.TrimSpace(*req.Email)
+		if err := validateEmail(email); err != nil {
+			return nil, err
+		}
+		updated.PendingEmail = email
+		if email == user.Email {
+			updated.PendingEmail = ""
+		}
+	}
+	emailChanged := updated.PendingEmail != user.PendingEmail
+	if updated.Username == user.Username && !emailChanged {
+		return user, nil
+	}
+
+	if updated.Username != user.Username {
+		if other, err := s.userRepo.GetByUsername(ctx, updated.Username); err == nil && other.ID != userID {
+			return nil, fmt.Errorf("%w: user with username %s already exists", domain.ErrConflict, updated.Username)
+		}
+	}
+	if emailChanged && updated.PendingEmail != "" {
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword)); err != nil {
+			return nil, fmt.Errorf("%w: current password is incorrect", domain.ErrInvalidInput)
+		}
+		if other, err := s.userRepo.GetByEmail(ctx, updated.PendingEmail); err == nil && other.ID != userID {
+			return nil, fmt.Errorf("%w: user with email %s already exists", domain.ErrConflict, updated.PendingEmail)
+		}
+	}
+
+	if err := s.userRepo.Update(ctx, &updated); err != nil {
+		return nil, err
+	}
+
+	if emailChanged {
+		// Only the latest pending address can be confirmed
+		if err := s.tokenRepo.InvalidateByUserID(ctx, userID, domain.PurposeChangeEmail); err != nil {
+			return nil, err
+		}
+		if updated.PendingEmail != "" {
+			if err := s.sendChangeEmailConfirmation(ctx, &updated); err != nil {
+				log.Println("[UpdateProfile] Error: ", err)
+			}
+			s.send(mail.Message{
+				To:      updated.Email,
+				Subject: "Your email address is being changed",
+				Body: fmt.Sprintf("Hi %s,\n\nSomeone asked to change the email address of your JobLog account to %s. "+
+					"It will change once the link sent there is opened. If this wasn't you, change your password right away.\n",
+					updated.Username, updated.PendingEmail),
+			})
+		}
+	}
+	return &updated, nil
+}
+
+func validateUsername(username string) error {
+	if username == "" {
+		return fmt.Errorf("%w: username cannot be empty", domain.ErrInvalidInput)
+	}
+	if len(username) > 255 {
+		return fmt.Errorf("%w: username must be at most 255 characters", domain.ErrInvalidInput)
+	}
+	return nil
+}
+
+func validateEmail(email string) error {
+	if addr, err := netmail.ParseAddress(email); err != nil || addr.Address != email || len(email) > 255 {
+		return fmt.Errorf("%w: %q is not a valid email address", domain.ErrInvalidInput, email)
+	}
+	return nil
+}
+
+// ChangePassword sets a new password after checking the current one. Every other session
+// is logged out; the one making the change stays logged in.
+func (s *AuthService) ChangePassword(ctx context.Context, userID, sessionID string, req domain.ChangePasswordRequest) error {
+	if err := validatePassword(req.NewPassword); err != nil {
+		return err
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword)); err != nil {
+		return fmt.Errorf("%w: current password is incorrect", domain.ErrInvalidInput)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("could not hash password: %w", err)
+	}
+	if err := s.userRepo.UpdatePassword(ctx, userID, string(hashedPassword)); err != nil {
+		return err
+	}
+	if err := s.sessionRepo.RevokeAllByUserID(ctx, userID, sessionID); err != nil {
+		return err
+	}
+	return s.tokenRepo.InvalidateByUserID(ctx, userID, domain.PurposeResetPassword)
+}
+
+// DeleteAccount permanently removes the user and all of their data.
+func (s *AuthService) DeleteAccount(ctx context.Context, userID string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	username := user.Username
+	if err := s.userRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+	return s.loginGuard.Forget(ctx, username)
+}
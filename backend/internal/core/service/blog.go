@@ -16,8 +16,8 @@ type BlogService struct {
 	userRepo domain.UserRepository
 }
 
-func NewBlogService(blogRepo domain.BlogRepository) *BlogService {
-	return &BlogService{blogRepo: blogRepo}
+func NewBlogService(blogRepo domain.BlogRepository, userRepo domain.UserRepository) *BlogService {
+	return &BlogService{blogRepo: blogRepo, userRepo: userRepo}
 }
 
 func (s *BlogService) Create(ctx context.Context, userID string, newPost domain.NewBlogPost) (*domain.BlogPost, error) {
@@ -37,6 +37,7 @@ func (s *BlogService) Create(ctx context.Context, userID string, newPost domain.
 		Slug:         generateSlug(newPost.Title),
 		Title:        newPost.Title,
 		Content:      newPost.Content,
+		AuthorID:     user.ID,
 		Author:       user.Username,
 		AuthorAvatar: authorAvatar,
 		CreatedAt:    time.Now(),
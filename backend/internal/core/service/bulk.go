@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"joblog/internal/core/domain"
+)
+
+const maxBulkItems = 500
+
+// Bulk applies one operation to many of the user's applications in a single transaction.
+// Every ID is checked and every change validated first; if any item fails, nothing is
+// written and the result says why for each item. Missing and foreign applications both
+// fail with "application not found".
+func (s *ApplicationService) Bulk(ctx context.Context, userID string, req domain.BulkRequest) (*domain.BulkResult, error) {
+	ids, err := bulkIDs(req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := strings.TrimSpace(req.StatusReason)
+	var workflow *domain.Workflow
+	switch req.Operation {
+	case domain.BulkSetStatus:
+		if req.Status == nil || *req.Status == "" {
+			return nil, fmt.Errorf("%w: set_status needs a status", domain.ErrInvalidInput)
+		}
+		if workflow, err = s.workflows.Get(ctx, userID); err != nil {
+			return nil, err
+		}
+	case domain.BulkAddTag:
+		tag, err := s.tags.GetByID(ctx, req.TagID)
+		if err != nil {
+			return nil, err
+		}
+		if tag.UserID != userID {
+			return nil, fmt.Errorf("tag %w", domain.ErrNotFound)
+		}
+	case domain.BulkArchive, domain.BulkRestore, domain.BulkDelete:
+	default:
+		return nil, fmt.Errorf("%w: unknown bulk operation %q", domain.ErrInvalidInput, req.Operation)
+	}
+
+	result := &domain.BulkResult{Results: make([]domain.BulkItemResult, len(ids))}
+	failed := false
+	today := time.Now().Format("2006-01-02")
+
+	// The applications are read and locked in the transaction that writes them, so a
+	// concurrent edit can't be overwritten with what was read before it
+	err = s.repo.LockAndApplyBatch(ctx, ids, func(apps []*domain.Application) (domain.ApplicationBatch, error) {
+		byID := make(map[string]*domain.Application, len(apps))
+		for _, app := range apps {
+			byID[app.ID] = app
+		}
+
+		var batch domain.ApplicationBatch
+		for i, id := range ids {
+			item := domain.BulkItemResult{ID: id, Status: domain.BulkItemUpdated}
+
+			current, ok := byID[id]
+			if !ok || current.UserID != userID {
+				item.Status, item.Error = domain.BulkItemFailed, "application not found"
+				result.Results[i] = item
+				failed = true
+				continue
+			}
+
+			// Work on a copy so nothing stored changes unless the whole batch goes through
+			app := *current
+			app.History = slices.Clone(current.History)
+
+			switch req.Operation {
+			case domain.BulkSetStatus:
+				to := *req.Status
+				if app.Status == to {
+					item.Status = domain.BulkItemUnchanged
+					break
+				}
+				if err := workflow.ValidateTransition(app.Status, to, reason); err != nil {
+					item.Status, item.Error = domain.BulkItemFailed, err.Error()
+					break
+				}
+				event := statusChangedEvent(app.Status, to)
+				if to == domain.StatusArchived {
+					event = archivedEvent(app.Status)
+				}
+				event.Reason = reason
+				app.History = append(app.History, event)
+				app.Status = to
+
+			case domain.BulkArchive:
+				if app.Status == domain.StatusArchived {
+					item.Status = domain.BulkItemUnchanged
+					break
+				}
+				app.History = append(app.History, archivedEvent(app.Status))
+				app.Status = domain.StatusArchived
+
+			case domain.BulkRestore:
+				if app.Status != domain.StatusArchived {
+					item.Status = domain.BulkItemUnchanged
+					break
+				}
+				status := statusBeforeArchive(app.History)
+				app.History = append(app.History, restoredEvent(status))
+				app.Status = status
+
+			case domain.BulkAddTag:
+				if slices.ContainsFunc(app.Tags, func(tag domain.Tag) bool { return tag.ID == req.TagID }) {
+					item.Status = domain.BulkItemUnchanged
+					break
+				}
+				app.History = append(app.History, fieldsEditedEvent([]string{"tags"}))
+				batch.TagAssignments = append(batch.TagAssignments, domain.TagAssignment{ApplicationID: id, TagID: req.TagID})
+
+			case domain.BulkDelete:
+				item.Status = domain.BulkItemDeleted
+				batch.Deletes = append(batch.Deletes, id)
+			}
+
+			if item.Status == domain.BulkItemFailed {
+				failed = true
+			}
+			if item.Status == domain.BulkItemUpdated {
+				app.UpdatedAt = today
+				batch.Updates = append(batch.Updates, &app)
+			}
+			result.Results[i] = item
+		}
+
+		if failed {
+			return domain.ApplicationBatch{}, nil
+		}
+		return batch, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Applied = !failed
+	return result, nil
+}
+
+// bulkIDs trims and de-duplicates the requested IDs, keeping their order.
+func bulkIDs(requested []string) ([]string, error) {
+	var ids []string
+	seen := make(map[string]bool, len(requested))
+	for _, id := range requested {
+		if id = strings.TrimSpace(id); id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: at least one application ID is required", domain.ErrInvalidInput)
+	}
+	if len(ids) > maxBulkItems {
+		return nil, fmt.Errorf("%w: at most %d applications can be changed at once", domain.ErrInvalidInput, maxBulkItems)
+	}
+	return ids, nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"joblog/internal/core/domain"
+	"joblog/pkg/auth"
+	"joblog/pkg/ical"
+)
+
+// CalendarEventSource contributes events to a user's calendar feed.
+type CalendarEventSource interface {
+	CalendarEvents(ctx context.Context, userID string) ([]ical.Event, error)
+}
+
+type CalendarService struct {
+	tokens  domain.CalendarTokenRepository
+	sources []CalendarEventSource
+}
+
+func NewCalendarService(tokens domain.CalendarTokenRepository, sources ...CalendarEventSource) *CalendarService {
+	return &CalendarService{tokens: tokens, sources: sources}
+}
+
+// CreateToken issues a new feed token for the user, invalidating the previous one.
+// The plain token is only ever returned here.
+func (s *CalendarService) CreateToken(ctx context.Context, userID string) (string, error) {
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.tokens.Save(ctx, userID, auth.HashToken(token)); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *CalendarService) RevokeToken(ctx context.Context, userID string) error {
+	return s.tokens.Delete(ctx, userID)
+}
+
+// Feed builds the calendar for whoever owns the token.
+func (s *CalendarService) Feed(ctx context.Context, token string) (*ical.Calendar, error) {
+	userID, err := s.tokens.GetUserIDByTokenHash(ctx, auth.HashToken(token))
+	if err != nil {
+		log.Println("[CalendarService.Feed] Error: ", err)
+		return nil, fmt.Errorf("calendar not found")
+	}
+
+	calendar := &ical.Calendar{ProdID: "-//JobLog//Calendar//EN", Name: "JobLog"}
+	for _, source := range s.sources {
+		events, err := source.CalendarEvents(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		calendar.Events = append(calendar.Events, events...)
+	}
+	return calendar, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"joblog/internal/core/domain"
+)
+
+type CompanyService struct {
+	repo    domain.CompanyRepository
+	appRepo domain.ApplicationRepository
+}
+
+func NewCompanyService(repo domain.CompanyRepository, appRepo domain.ApplicationRepository) *CompanyService {
+	return &CompanyService{repo: repo, appRepo: appRepo}
+}
+
+func (s *CompanyService) List(ctx context.Context, userID string) ([]*domain.CompanySummary, error) {
+	return s.repo.ListSummaries(ctx, userID)
+}
+
+func (s *CompanyService) GetByID(ctx context.Context, userID, companyID string) (*domain.Company, error) {
+	company, err := s.repo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if company.UserID != userID {
+		return nil, fmt.Errorf("company %w", domain.ErrNotFound)
+	}
+	return company, nil
+}
+
+// Delete removes a company the user no longer has any applications to, archived ones
+// included. Companies are created implicitly, so this is how stale ones are cleaned up.
+func (s *CompanyService) Delete(ctx context.Context, userID, companyID string) error {
+	if _, err := s.GetByID(ctx, userID, companyID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, companyID)
+}
+
+// GetDetail returns the company with every application to it, archived ones included.
+func (s *CompanyService) GetDetail(ctx context.Context, userID, companyID string) (*domain.CompanyDetail, error) {
+	company, err := s.GetByID(ctx, userID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	apps, err := s.appRepo.ListByCompanyID(ctx, company.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &domain.CompanyDetail{
+		CompanySummary: domain.CompanySummary{
+			Company:          *company,
+			ApplicationCount: len(apps),
+			StatusBreakdown:  make(map[domain.ApplicationStatus]int),
+		},
+		Applications: apps,
+	}
+	for _, app := range apps {
+		detail.StatusBreakdown[app.Status]++
+		if app.Date > detail.LastAppliedOn {
+			detail.LastAppliedOn = app.Date
+		}
+	}
+	return detail, nil
+}
+
+func (s *CompanyService) Update(ctx context.Context, userID, companyID string, updateData domain.CompanyUpdate) (*domain.Company, error) {
+	current, err := s.GetByID(ctx, userID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Work on a copy so a failed validation leaves the stored company untouched
+	company := *current
+	if updateData.Name != nil {
+		company.Name = strings.TrimSpace(*updateData.Name)
+		company.NormalizedName = domain.NormalizeCompanyName(company.Name)
+	}
+	if updateData.Website != nil {
+		company.Website = strings.TrimSpace(*updateData.Website)
+	}
+	if updateData.Industry != nil {
+		company.Industry = strings.TrimSpace(*updateData.Industry)
+	}
+	if updateData.Size != nil {
+		company.Size = strings.TrimSpace(*updateData.Size)
+	}
+	if updateData.Location != nil {
+		company.Location = strings.TrimSpace(*updateData.Location)
+	}
+	if updateData.Notes != nil {
+		company.Notes = *updateData.Notes
+	}
+	company.UpdatedAt = time.Now()
+
+	if company.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+	}
+	if company.Website != "" && !isHTTPURL(company.Website) {
+		return nil, fmt.Errorf("%w: website must be an http(s) URL", domain.ErrInvalidInput)
+	}
+	if err := s.repo.Update(ctx, &company); err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"joblog/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type ContactService struct {
+	repo       domain.ContactRepository
+	appService *ApplicationService
+}
+
+func NewContactService(repo domain.ContactRepository, appService *ApplicationService) *ContactService {
+	return &ContactService{repo: repo, appService: appService}
+}
+
+func (s *ContactService) Create(ctx context.Context, userID string, newContact domain.NewContact) (*domain.Contact, error) {
+	now := time.Now()
+	contact := &domain.Contact{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		Name:        strings.TrimSpace(newContact.Name),
+		Email:       strings.TrimSpace(newContact.Email),
+		Phone:       strings.TrimSpace(newContact.Phone),
+		LinkedInURL: strings.TrimSpace(newContact.LinkedInURL),
+		Company:     strings.TrimSpace(newContact.Company),
+		Title:       strings.TrimSpace(newContact.Title),
+		Notes:       newContact.Notes,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := validateContact(contact); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, contact); err != nil {
+		return nil, err
+	}
+	return contact, nil
+}
+
+// List returns the user's contacts, optionally narrowed by a search query.
+func (s *ContactService) List(ctx context.Context, userID, query string) ([]*domain.Contact, error) {
+	return s.repo.List(ctx, userID, strings.TrimSpace(query))
+}
+
+func (s *ContactService) GetByID(ctx context.Context, userID, contactID string) (*domain.Contact, error) {
+	contact, err := s.repo.GetByID(ctx, contactID)
+	if err != nil {
+		return nil, err
+	}
+	if contact.UserID != userID {
+		return nil, fmt.Errorf("contact %w", domain.ErrNotFound)
+	}
+	return contact, nil
+}
+
+func (s *ContactService) Update(ctx context.Context, userID, contactID string, updateData domain.ContactUpdate) (*domain.Contact, error) {
+	current, err := s.GetByID(ctx, userID, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Work on a copy so a failed validation leaves the stored contact untouched
+	contact := *current
+	if updateData.Name != nil {
+		contact.Name = strings.TrimSpace(*updateData.Name)
+	}
+	if updateData.Email != nil {
+		contact.Email = strings.TrimSpace(*updateData.Email)
+	}
+	if updateData.Phone != nil {
+		contact.Phone = strings.TrimSpace(*updateData.Phone)
+	}
+	if updateData.LinkedInURL != nil {
+		contact.LinkedInURL = strings.TrimSpace(*updateData.LinkedInURL)
+	}
+	if updateData.Company != nil {
+		contact.Company = strings.TrimSpace(*updateData.Company)
+	}
+	if updateData.Title != nil {
+		contact.Title = strings.TrimSpace(*updateData.Title)
+	}
+	if updateData.Notes != nil {
+		contact.Notes = *updateData.Notes
+	}
+	contact.UpdatedAt = time.Now()
+
+	if err := validateContact(&contact); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, &contact); err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// Delete removes the contact and unlinks it from every application.
+func (s *ContactService) Delete(ctx context.Context, userID, contactID string) error {
+	if _, err := s.GetByID(ctx, userID, contactID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, contactID)
+}
+
+// |--- Application links ---
+
+func (s *ContactService) ListForApplication(ctx context.Context, userID, appID string) ([]domain.ApplicationContact, error) {
+	if _, err := s.appService.GetByID(ctx, userID, appID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByApplicationID(ctx, appID)
+}
+
+// Link attaches one of the user's contacts to one of their applications. Linking an
+// already linked contact just changes its role.
+func (s *ContactService) Link(ctx context.Context, userID, appID, contactID string, role domain.ContactRole) ([]domain.ApplicationContact, error) {
+	if err := validateContactRole(role); err != nil {
+		return nil, err
+	}
+	if _, err := s.appService.GetByID(ctx, userID, appID); err != nil {
+		return nil, err
+	}
+	if _, err := s.GetByID(ctx, userID, contactID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Link(ctx, appID, contactID, role); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByApplicationID(ctx, appID)
+}
+
+func (s *ContactService) Unlink(ctx context.Context, userID, appID, contactID string) error {
+	if _, err := s.appService.GetByID(ctx, userID, appID); err != nil {
+		return err
+	}
+	return s.repo.Unlink(ctx, appID, contactID)
+}
+
+func validateContact(contact *domain.Contact) error {
+	if contact.Name == "" {
+		return fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+	}
+	if contact.Email != "" {
+		if addr, err := mail.ParseAddress(contact.Email); err != nil || addr.Address != contact.Email {
+			return fmt.Errorf("%w: %q is not a valid email address", domain.ErrInvalidInput, contact.Email)
+		}
+	}
+	if contact.LinkedInURL != "" && !isHTTPURL(contact.LinkedInURL) {
+		return fmt.Errorf("%w: LinkedIn URL must be an http(s) URL", domain.ErrInvalidInput)
+	}
+	return nil
+}
+
+func validateContactRole(role domain.ContactRole) error {
+	switch role {
+	case domain.ContactRecruiter, domain.ContactHiringManager, domain.ContactReferrer, domain.ContactInterviewer, domain.ContactOther:
+		return nil
+	}
+	return fmt.Errorf("%w: unknown contact role %q", domain.ErrInvalidInput, role)
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"joblog/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxCustomFieldNameLength = 100
+	maxCustomTextLength      = 1000
+)
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+type CustomFieldService struct {
+	repo domain.CustomFieldRepository
+}
+
+func NewCustomFieldService(repo domain.CustomFieldRepository) *CustomFieldService {
+	return &CustomFieldService{repo: repo}
+}
+
+func (s *CustomFieldService) List(ctx context.Context, userID string) ([]*domain.CustomField, error) {
+	return s.repo.ListByUserID(ctx, userID)
+}
+
+func (s *CustomFieldService) Create(ctx context.Context, userID string, newField domain.NewCustomField) (*domain.CustomField, error) {
+	now := time.Now()
+	field := &domain.CustomField{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Key:       strings.TrimSpace(newField.Key),
+		Name:      strings.TrimSpace(newField.Name),
+		Type:      newField.Type,
+		Options:   trimOptions(newField.Options),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if field.Key == "" {
+		field.Key = customFieldKey(field.Name)
+	}
+	if err := validateCustomField(field); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func (s *CustomFieldService) GetByID(ctx context.Context, userID, fieldID string) (*domain.CustomField, error) {
+	field, err := s.repo.GetByID(ctx, fieldID)
+	if err != nil {
+		return nil, err
+	}
+	if field.UserID != userID {
+		return nil, fmt.Errorf("custom field %w", domain.ErrNotFound)
+	}
+	return field, nil
+}
+
+// Update renames a field or changes an enum's options. Values already stored are kept,
+// even if they are no longer among the options.
+func (s *CustomFieldService) Update(ctx context.Context, userID, fieldID string, updateData domain.CustomFieldUpdate) (*domain.CustomField, error) {
+	current, err := s.GetByID(ctx, userID, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	field := *current
+	if updateData.Name != nil {
+		field.Name = strings.TrimSpace(*updateData.Name)
+	}
+	if updateData.Options != nil {
+		field.Options = trimOptions(*updateData.Options)
+	}
+	field.UpdatedAt = time.Now()
+
+	if err := validateCustomField(&field); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, &field); err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// Delete removes the field along with its values on every application.
+func (s *CustomFieldService) Delete(ctx context.Context, userID, fieldID string) error {
+	if _, err := s.GetByID(ctx, userID, fieldID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, fieldID)
+}
+
+// customFieldKey derives a key like "visa_sponsorship" from a field name.
+func customFieldKey(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			underscore = false
+		} else {
+			underscore = true
+		}
+	}
+	return b.String()
+}
+
+func trimOptions(options []string) []string {
+	trimmed := []string{}
+	for _, option := range options {
+		if option = strings.TrimSpace(option); option != "" {
+			trimmed = append(trimmed, option)
+		}
+	}
+	return trimmed
+}
+
+func validateCustomField(field *domain.CustomField) error {
+	if field.Name == "" {
+		return fmt.Errorf("%w: custom field name is required", domain.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(field.Name) > maxCustomFieldNameLength {
+		return fmt.Errorf("%w: custom field name cannot be longer than %d characters", domain.ErrInvalidInput, maxCustomFieldNameLength)
+	}
+	if !customFieldKeyPattern.MatchString(field.Key) {
+		return fmt.Errorf("%w: key %q must start with a letter and contain only a-z, 0-9 and _", domain.ErrInvalidInput, field.Key)
+	}
+
+	switch field.Type {
+	case domain.FieldEnum:
+		if len(field.Options) == 0 {
+			return fmt.Errorf("%w: an enum field needs at least one option", domain.ErrInvalidInput)
+		}
+		for i, option := range field.Options {
+			if slices.Contains(field.Options[:i], option) {
+				return fmt.Errorf("%w: option %q is listed twice", domain.ErrInvalidInput, option)
+			}
+		}
+	case domain.FieldText, domain.FieldNumber, domain.FieldDate, domain.FieldBool:
+		if len(field.Options) > 0 {
+			return fmt.Errorf("%w: only enum fields have options", domain.ErrInvalidInput)
+		}
+	default:
+		return fmt.Errorf("%w: unknown custom field type %q", domain.ErrInvalidInput, field.Type)
+	}
+	return nil
+}
+
+// customFieldValue checks a decoded JSON value against the field's type and returns it in
+// canonical form. An empty text value comes back as nil, clearing the field.
+func customFieldValue(field *domain.CustomField, value any) (any, error) {
+	invalid := func() (any, error) {
+		return nil, fmt.Errorf("%w: %s must be a %s value", domain.ErrInvalidInput, field.Name, field.Type)
+	}
+
+	switch field.Type {
+	case domain.FieldText:
+		s, ok := value.(string)
+		if !ok {
+			return invalid()
+		}
+		if s = strings.TrimSpace(s); s == "" {
+			return nil, nil
+		}
+		if utf8.RuneCountInString(s) > maxCustomTextLength {
+			return nil, fmt.Errorf("%w: %s cannot be longer than %d characters", domain.ErrInvalidInput, field.Name, maxCustomTextLength)
+		}
+		return s, nil
+	case domain.FieldNumber:
+		n, ok := value.(float64)
+		if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+			return invalid()
+		}
+		return n, nil
+	case domain.FieldDate:
+		s, ok := value.(string)
+		if !ok {
+			return invalid()
+		}
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			return nil, fmt.Errorf("%w: %s must be in YYYY-MM-DD format", domain.ErrInvalidInput, field.Name)
+		}
+		return s, nil
+	case domain.FieldEnum:
+		s, ok := value.(string)
+		if !ok || !slices.Contains(field.Options, s) {
+			return nil, fmt.Errorf("%w: %s must be one of %s", domain.ErrInvalidInput, field.Name, strings.Join(field.Options, ", "))
+		}
+		return s, nil
+	case domain.FieldBool:
+		b, ok := value.(bool)
+		if !ok {
+			return invalid()
+		}
+		return b, nil
+	}
+	return invalid()
+}
+
+// applyCustomFields validates the changes against the user's field definitions and applies
+// them to values. A nil change clears the field. It returns the keys whose value changed.
+func applyCustomFields(fields []*domain.CustomField, values, changes domain.CustomFieldValues) ([]string, error) {
+	byKey := make(map[string]*domain.CustomField, len(fields))
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var changed []string
+	for _, key := range keys {
+		field, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown custom field %q", domain.ErrInvalidInput, key)
+		}
+
+		var value any
+		if changes[key] != nil {
+			var err error
+			if value, err = customFieldValue(field, changes[key]); err != nil {
+				return nil, err
+			}
+		}
+
+		current, exists := values[key]
+		switch {
+		case value == nil && exists:
+			delete(values, key)
+		case value != nil && (!exists || current != value):
+			values[key] = value
+		default:
+			continue
+		}
+		changed = append(changed, key)
+	}
+	return changed, nil
+}
+
+// parseCustomFieldFilter converts the raw query string values of a list filter to the types
+// of their fields, so they compare equal to the stored values.
+func parseCustomFieldFilter(fields []*domain.CustomField, raw domain.CustomFieldValues) (domain.CustomFieldValues, error) {
+	byKey := make(map[string]*domain.CustomField, len(fields))
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+
+	parsed := make(domain.CustomFieldValues, len(raw))
+	for key, value := range raw {
+		field, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown custom field %q", domain.ErrInvalidInput, key)
+		}
+		s, _ := value.(string)
+
+		var typed any = s
+		switch field.Type {
+		case domain.FieldNumber:
+			n, err := strconv.ParseFloat(s, 64)
+			if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+				return nil, fmt.Errorf("%w: filter on %s must be a number", domain.ErrInvalidInput, field.Name)
+			}
+			typed = n
+		case domain.FieldBool:
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, fmt.Errorf("%w: filter on %s must be true or false", domain.ErrInvalidInput, field.Name)
+			}
+			typed = b
+		}
+		parsed[key] = typed
+	}
+	return parsed, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"joblog/internal/core/domain"
+)
+
+func TestParseCustomFieldFilterNumbers(t *testing.T) {
+	fields := []*domain.CustomField{{Key: "salary", Name: "Salary", Type: domain.FieldNumber}}
+
+	tests := []struct {
+		raw     string
+		want    float64
+		wantErr bool
+	}{
+		{"120000", 120000, false},
+		{"1.5e3", 1500, false},
+		{"-2", -2, false},
+		{"lots", 0, true},
+		{"", 0, true},
+		{"NaN", 0, true},
+		{"Inf", 0, true},
+		{"+Inf", 0, true},
+		{"-infinity", 0, true},
+		{"1e400", 0, true},
+	}
+	for _, tt := range tests {
+		parsed, err := parseCustomFieldFilter(fields, domain.CustomFieldValues{"salary": tt.raw})
+		if tt.wantErr {
+			if !errors.Is(err, domain.ErrInvalidInput) {
+				t.Errorf("parseCustomFieldFilter(%q) = %v, %v; want ErrInvalidInput", tt.raw, parsed, err)
+			}
+			continue
+		}
+		if err != nil || parsed["salary"] != tt.want {
+			t.Errorf("parseCustomFieldFilter(%q) = %v, %v; want %v", tt.raw, parsed["salary"], err, tt.want)
+		}
+	}
+}
+
+func TestCustomFieldValueRejectsNonFiniteNumbers(t *testing.T) {
+	field := &domain.CustomField{Key: "salary", Name: "Salary", Type: domain.FieldNumber}
+	for _, n := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := customFieldValue(field, n); !errors.Is(err, domain.ErrInvalidInput) {
+			t.Errorf("customFieldValue(%v) = %v, want ErrInvalidInput", n, err)
+		}
+	}
+	if got, err := customFieldValue(field, 42.5); err != nil || got != 42.5 {
+		t.Errorf("customFieldValue(42.5) = %v, %v", got, err)
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"joblog/internal/core/domain"
+)
+
+// DuplicatePolicy controls how eagerly ApplicationService.Create flags a new
+// application as a likely duplicate of an existing one at the same company.
+type DuplicatePolicy struct {
+	// Window bounds fuzzy role matches: they only count if the two application dates
+	// are at most this far apart. Identical roles are duplicates regardless of date.
+	Window time.Duration
+	// MinSimilarity is the role similarity, from 0 to 1, at which roles fuzzily match.
+	MinSimilarity float64
+}
+
+func DefaultDuplicatePolicy() DuplicatePolicy {
+	return DuplicatePolicy{Window: 90 * 24 * time.Hour, MinSimilarity: 0.6}
+}
+
+// roleAbbreviations expands the common shorthands so "Sr. SWE" and "Senior Software Engineer" agree.
+var roleAbbreviations = map[string]string{
+	"sr":   "senior",
+	"snr":  "senior",
+	"jr":   "junior",
+	"eng":  "engineer",
+	"engr": "engineer",
+	"mgr":  "manager",
+	"dev":  "developer",
+	"swe":  "software engineer",
+	"sde":  "software development engineer",
+	"pm":   "product manager",
+}
+
+// roleTokens lower-cases a role title, splits it into words and expands abbreviations.
+func roleTokens(role string) []string {
+	words := strings.FieldsFunc(strings.ToLower(role), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if expanded, ok := roleAbbreviations[word]; ok {
+			tokens = append(tokens, strings.Fields(expanded)...)
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// roleSimilarity is the share of distinct words the two roles have in common, where
+// longer words that differ by a single typo still count as the same word.
+func roleSimilarity(a, b []string) float64 {
+	setA, setB := uniqueWords(a), uniqueWords(b)
+	if len(setA) == 0 || len(setB) == 0 {
+		return 0
+	}
+
+	matched := 0
+	used := make(map[string]bool)
+	for wordA := range setA {
+		for wordB := range setB {
+			if used[wordB] {
+				continue
+			}
+			if wordA == wordB || (len(wordA) >= 5 && len(wordB) >= 5 && withinOneEdit(wordA, wordB)) {
+				matched++
+				used[wordB] = true
+				break
+			}
+		}
+	}
+	return float64(matched) / float64(len(setA)+len(setB)-matched)
+}
+
+func uniqueWords(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+// withinOneEdit reports whether a and b differ by at most one insertion, deletion or substitution.
+func withinOneEdit(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	if len(ra)-len(rb) > 1 {
+		return false
+	}
+
+	i, j, edits := 0, 0, 0
+	for i < len(ra) && j < len(rb) {
+		if ra[i] == rb[j] {
+			i++
+			j++
+			continue
+		}
+		edits++
+		if edits > 1 {
+			return false
+		}
+		if len(ra) > len(rb) {
+			i++ // Deletion from the longer word
+		} else {
+			i++
+			j++ // Substitution
+		}
+	}
+	return edits+(len(ra)-i) <= 1
+}
+
+// findDuplicates returns the IDs of candidates that look like the same application as
+// the new one. All candidates are assumed to be at the same (normalized) company.
+func (p DuplicatePolicy) findDuplicates(role string, date time.Time, candidates []*domain.Application) []string {
+	tokens := roleTokens(role)
+	normalized := strings.Join(tokens, " ")
+
+	var ids []string
+	for _, candidate := range candidates {
+		candidateTokens := roleTokens(candidate.Role)
+		if strings.Join(candidateTokens, " ") == normalized {
+			ids = append(ids, candidate.ID)
+			continue
+		}
+
+		candidateDate, err := time.Parse("2006-01-02", candidate.Date)
+		if err != nil {
+			continue
+		}
+		gap := date.Sub(candidateDate)
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap <= p.Window && roleSimilarity(tokens, candidateTokens) >= p.MinSimilarity {
+			ids = append(ids, candidate.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"joblog/internal/core/domain"
+)
+
+func TestWithinOneEdit(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"kitten", "kitten", true},
+		{"kitten", "sitten", true},
+		{"kitten", "sitting", false},
+		{"abc", "abcd", true},
+		{"abcd", "abc", true},
+		{"abc", "abcde", false},
+		{"", "a", true},
+		{"", "", true},
+		{"ab", "ba", false},
+		{"developer", "develper", true},
+		{"engineer", "enginere", false},
+		{"héllo", "hello", true},
+		{"abcd", "axcy", false},
+	}
+	for _, tt := range tests {
+		if got := withinOneEdit(tt.a, tt.b); got != tt.want {
+			t.Errorf("withinOneEdit(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRoleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"Software Engineer", "software engineer", 1},
+		{"Sr. SWE", "Senior Software Engineer", 1},
+		{"Software Engineer", "Sofware Engineer", 1},
+		{"Data Engineer", "Senior Data Engineer", 2.0 / 3},
+		{"Backend Engineer", "Frontend Engineer", 1.0 / 3},
+		{"QA", "QB", 0}, // Short words must match exactly
+		{"Engineer Engineer", "Engineer", 1},
+		{"", "Engineer", 0},
+		{"Designer", "Accountant", 0},
+	}
+	for _, tt := range tests {
+		got := roleSimilarity(roleTokens(tt.a), roleTokens(tt.b))
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("roleSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if reverse := roleSimilarity(roleTokens(tt.b), roleTokens(tt.a)); math.Abs(reverse-got) > 1e-9 {
+			t.Errorf("roleSimilarity is not symmetric for %q and %q: %v vs %v", tt.a, tt.b, got, reverse)
+		}
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	candidates := []*domain.Application{
+		{ID: "same-role-long-ago", Role: "Senior Software Engineer", Date: "2024-01-10"},
+		{ID: "similar-role-recent", Role: "Senior Backend Engineer", Date: "2026-02-20"},
+		{ID: "similar-role-long-ago", Role: "Senior Backend Engineer", Date: "2025-01-10"},
+		{ID: "other-role-recent", Role: "Product Designer", Date: "2026-03-01"},
+	}
+	date := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		role string
+		want []string
+	}{
+		{"Sr. SWE", []string{"same-role-long-ago"}},
+		{"Senior Backend Engineer II", []string{"similar-role-recent"}},
+		{"Accountant", nil},
+	}
+	for _, tt := range tests {
+		got := DefaultDuplicatePolicy().findDuplicates(tt.role, date, candidates)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("findDuplicates(%q) = %v, want %v", tt.role, got, tt.want)
+		}
+	}
+}
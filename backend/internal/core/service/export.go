@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"joblog/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// Export calls each for every application matching the filter, notes and history
+// included, in the filter's order. The filter's cursor and limit are ignored. An invalid
+// filter is reported before each is first called.
+func (s *ApplicationService) Export(ctx context.Context, userID string, filter domain.ApplicationFilter, each func(*domain.Application) error) error {
+	filter, err := s.exportFilter(ctx, userID, filter)
+	if err != nil {
+		return err
+	}
+	return s.export(ctx, userID, filter, each)
+}
+
+func (s *ApplicationService) exportFilter(ctx context.Context, userID string, filter domain.ApplicationFilter) (domain.ApplicationFilter, error) {
+	filter.Cursor = nil
+	filter.Limit = maxListLimit
+	filter.WithDetails = true
+	return s.normalizeFilter(ctx, userID, filter)
+}
+
+// export pages through the applications matching an already normalized filter.
+func (s *ApplicationService) export(ctx context.Context, userID string, filter domain.ApplicationFilter, each func(*domain.Application) error) error {
+	for {
+		page, err := s.repo.List(ctx, userID, filter)
+		if err != nil {
+			return err
+		}
+		for _, app := range page.Applications {
+			if err := each(app); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == nil {
+			return nil
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
+
+// ExportTable writes the matching applications as rows for a spreadsheet, starting with
+// a header row. Custom fields get a column each; tags, notes and history are flattened
+// into one cell apiece. Like Export, an invalid filter is reported before any row is written.
+func (s *ApplicationService) ExportTable(ctx context.Context, userID string, filter domain.ApplicationFilter, writeRow func([]string) error) error {
+	filter, err := s.exportFilter(ctx, userID, filter)
+	if err != nil {
+		return err
+	}
+	fields, err := s.customFields.ListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	header := []string{"ID", "Company", "Role", "Date", "Status", "Updated", "Job URL", "Location", "Work Mode",
+		"Salary Min", "Salary Max", "Salary Currency", "Employment Type", "Source", "Priority", "Job Description"}
+	for _, field := range fields {
+		header = append(header, field.Name)
+	}
+	header = append(header, "Tags", "Notes", "History")
+	if err := writeRow(header); err != nil {
+		return err
+	}
+
+	return s.export(ctx, userID, filter, func(app *domain.Application) error {
+		posting := app.JobPosting
+		row := []string{app.ID, app.Company, app.Role, app.Date, string(app.Status), app.UpdatedAt,
+			posting.JobURL, posting.Location, string(posting.WorkMode), formatInt(posting.SalaryMin), formatInt(posting.SalaryMax),
+			posting.SalaryCurrency, string(posting.EmploymentType), string(posting.Source), string(posting.Priority), posting.JobDescription}
+		for _, field := range fields {
+			row = append(row, formatCustomFieldValue(app.CustomFields[field.Key]))
+		}
+
+		tags := make([]string, len(app.Tags))
+		for i, tag := range app.Tags {
+			tags[i] = tag.Name
+		}
+		notes := make([]string, len(app.Notes))
+		for i, note := range app.Notes {
+			notes[i] = note.CreatedAt.Format("2006-01-02") + ": " + note.Content
+		}
+		history := make([]string, len(app.History))
+		for i, event := range app.History {
+			history[i] = event.Date.Format("2006-01-02") + ": " + event.Event
+			if event.Reason != "" {
+				history[i] += " (" + event.Reason + ")"
+			}
+		}
+		row = append(row, strings.Join(tags, ", "), strings.Join(notes, "\n"), strings.Join(history, "\n"))
+		return writeRow(row)
+	})
+}
+
+func formatInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+func formatCustomFieldValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// BackupHeader returns the parts of a backup other than the applications, which are
+// streamed through Export.
+func (s *ApplicationService) BackupHeader(ctx context.Context, userID string) (*domain.Backup, error) {
+	summaries, err := s.tags.ListSummaries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := s.customFields.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	backup := &domain.Backup{
+		Version:      domain.BackupVersion,
+		ExportedAt:   time.Now().UTC(),
+		Tags:         make([]domain.Tag, len(summaries)),
+		CustomFields: fields,
+	}
+	for i, summary := range summaries {
+		backup.Tags[i] = summary.Tag
+	}
+	return backup, nil
+}
+
+// RestoreBackup recreates the applications of a JSON backup in the user's account, which
+// must not have any applications yet. Everything gets a new ID; notes, history and custom
+// field values are kept as they were. Tags and custom fields are matched to the account's
+// own by name and key, and created where missing, in the same transaction as the applications.
+func (s *ApplicationService) RestoreBackup(ctx context.Context, userID string, backup *domain.Backup) (*domain.RestoreResult, error) {
+	if backup.Version != domain.BackupVersion {
+		return nil, fmt.Errorf("%w: unsupported backup version %d; expected %d", domain.ErrInvalidInput, backup.Version, domain.BackupVersion)
+	}
+	existing, err := s.repo.List(ctx, userID, domain.ApplicationFilter{IncludeArchived: true, Sort: domain.SortByDate, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(existing.Applications) > 0 {
+		return nil, fmt.Errorf("%w: a backup can only be restored into an account without applications", domain.ErrConflict)
+	}
+
+	// Build and validate every application before anything is written
+	apps := make([]*domain.Application, len(backup.Applications))
+	validStatuses := make(map[domain.ApplicationStatus]bool)
+	for i, original := range backup.Applications {
+		if original == nil || strings.TrimSpace(original.Company) == "" {
+			return nil, fmt.Errorf("%w: application %d has no company", domain.ErrInvalidInput, i+1)
+		}
+		if _, err := time.Parse("2006-01-02", original.Date); err != nil {
+			return nil, fmt.Errorf("%w: application %d has an invalid date %q", domain.ErrInvalidInput, i+1, original.Date)
+		}
+		if original.Status == "" {
+			return nil, fmt.Errorf("%w: application %d has no status", domain.ErrInvalidInput, i+1)
+		}
+		// Archived is never part of a workflow but is a fine status to restore
+		if original.Status != domain.StatusArchived && !validStatuses[original.Status] {
+			if err := s.workflows.ValidateInitial(ctx, userID, original.Status); err != nil {
+				return nil, fmt.Errorf("application %d: %w", i+1, err)
+			}
+			validStatuses[original.Status] = true
+		}
+		posting := original.JobPosting
+		normalizeJobPosting(&posting)
+		if err := validateJobPosting(&posting); err != nil {
+			return nil, fmt.Errorf("application %d: %w", i+1, err)
+		}
+
+		app := &domain.Application{
+			ID:           uuid.NewString(),
+			UserID:       userID,
+			Company:      original.Company,
+			Role:         original.Role,
+			Date:         original.Date,
+			UpdatedAt:    original.UpdatedAt,
+			Status:       original.Status,
+			JobPosting:   posting,
+			CustomFields: domain.CustomFieldValues{},
+			Tags:         []domain.Tag{},
+			Notes:        make([]domain.Note, len(original.Notes)),
+			History:      original.History,
+		}
+		for j, note := range original.Notes {
+			note.ID = uuid.NewString()
+			app.Notes[j] = note
+		}
+		if len(app.History) == 0 {
+			app.History = []domain.HistoryEvent{createdEvent(app.Status)}
+		}
+		apps[i] = app
+	}
+
+	fields, newFields, err := s.restoreCustomFields(ctx, userID, backup.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, newTags, err := s.restoreTags(ctx, userID, backup.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := domain.ApplicationBatch{Tags: newTags, CustomFields: newFields, Creates: apps}
+	for i, original := range backup.Applications {
+		if _, err := applyCustomFields(fields, apps[i].CustomFields, original.CustomFields); err != nil {
+			return nil, fmt.Errorf("application %d: %w", i+1, err)
+		}
+		for _, tag := range original.Tags {
+			tagID, ok := tagIDs[tag.ID]
+			if !ok {
+				return nil, fmt.Errorf("%w: application %d has tag %q, which is not in the backup", domain.ErrInvalidInput, i+1, tag.Name)
+			}
+			batch.TagAssignments = append(batch.TagAssignments, domain.TagAssignment{ApplicationID: apps[i].ID, TagID: tagID})
+		}
+	}
+
+	if err := s.repo.ApplyBatch(ctx, batch); err != nil {
+		return nil, err
+	}
+	return &domain.RestoreResult{Applications: len(apps), Tags: len(newTags), CustomFields: len(newFields)}, nil
+}
+
+// restoreCustomFields matches the backed up custom fields to the user's by key. It returns
+// the fields the user will have once the missing ones, also returned, are created.
+func (s *ApplicationService) restoreCustomFields(ctx context.Context, userID string, backedUp []*domain.CustomField) ([]*domain.CustomField, []*domain.CustomField, error) {
+	fields, err := s.customFields.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	byKey := make(map[string]*domain.CustomField, len(fields))
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+
+	var missing []*domain.CustomField
+	now := time.Now()
+	for _, original := range backedUp {
+		if existing, ok := byKey[original.Key]; ok {
+			if existing.Type != original.Type {
+				return nil, nil, fmt.Errorf("%w: custom field %q is a %s field here but a %s field in the backup", domain.ErrConflict, original.Key, existing.Type, original.Type)
+			}
+			continue
+		}
+		field := &domain.CustomField{
+			ID:        uuid.NewString(),
+			UserID:    userID,
+			Key:       original.Key,
+			Name:      strings.TrimSpace(original.Name),
+			Type:      original.Type,
+			Options:   trimOptions(original.Options),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := validateCustomField(field); err != nil {
+			return nil, nil, err
+		}
+		byKey[field.Key] = field
+		fields = append(fields, field)
+		missing = append(missing, field)
+	}
+	return fields, missing, nil
+}
+
+// restoreTags maps the backup's tag IDs to the user's tags of the same name, ignoring case.
+// Tags the user doesn't have yet are returned to be created.
+func (s *ApplicationService) restoreTags(ctx context.Context, userID string, backedUp []domain.Tag) (map[string]string, []*domain.Tag, error) {
+	summaries, err := s.tags.ListSummaries(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	byName := make(map[string]string, len(summaries))
+	for _, summary := range summaries {
+		byName[strings.ToLower(summary.Name)] = summary.ID
+	}
+
+	ids := make(map[string]string, len(backedUp))
+	var missing []*domain.Tag
+	for _, original := range backedUp {
+		name := strings.TrimSpace(original.Name)
+		if id, ok := byName[strings.ToLower(name)]; ok {
+			ids[original.ID] = id
+			continue
+		}
+		tag := &domain.Tag{ID: uuid.NewString(), UserID: userID, Name: name, Color: strings.ToLower(original.Color)}
+		if tag.Color == "" {
+			tag.Color = defaultTagColor
+		}
+		if err := validateTag(tag); err != nil {
+			return nil, nil, err
+		}
+		byName[strings.ToLower(name)] = tag.ID
+		ids[original.ID] = tag.ID
+		missing = append(missing, tag)
+	}
+	return ids, missing, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"joblog/internal/core/domain"
+)
+
+// Constructors for the history events emitted by ApplicationService.
+// Event keeps the wording of the original free-text messages.
+
+func createdEvent(status domain.ApplicationStatus) domain.HistoryEvent {
+	return domain.HistoryEvent{
+		Date:     time.Now(),
+		Type:     domain.EventCreated,
+		ToStatus: status,
+		Event:    "Application created with status: " + string(status),
+	}
+}
+
+// importedEvent replaces createdEvent for applications imported from a file.
+func importedEvent(status domain.ApplicationStatus, source string) domain.HistoryEvent {
+	return domain.HistoryEvent{
+		Date:     time.Now(),
+		Type:     domain.EventCreated,
+		ToStatus: status,
+		Event:    "Application imported from " + source + " with status: " + string(status),
+	}
+}
+
+func statusChangedEvent(from, to domain.ApplicationStatus) domain.HistoryEvent {
+	return domain.HistoryEvent{
+		Date:       time.Now(),
+		Type:       domain.EventStatusChanged,
+		FromStatus: from,
+		ToStatus:   to,
+		Event:      "Status updated to: " + string(to),
+	}
+}
+
+func fieldsEditedEvent(fields []string) domain.HistoryEvent {
+	return domain.HistoryEvent{
+		Date:   time.Now(),
+		Type:   domain.EventFieldEdited,
+		Fields: fields,
+		Event:  "Updated " + strings.Join(fields, ", "),
+	}
+}
+
+func noteAddedEvent() domain.HistoryEvent {
+	return domain.HistoryEvent{
+		Date:  time.Now(),
+		Type:  domain.EventNoteAdded,
+		Event: "Note added",
+	}
+}
+
+func archivedEvent(from domain.ApplicationStatus) domain.HistoryEvent {
+	return domain.HistoryEvent{
+		Date:       time.Now(),
+		Type:       domain.EventArchived,
+		FromStatus: from,
+		ToStatus:   domain.StatusArchived,
+		Event:      "Application archived",
+	}
+}
+
+func restoredEvent(to domain.ApplicationStatus) domain.HistoryEvent {
+	return domain.HistoryEvent{
+		Date:       time.Now(),
+		Type:       domain.EventRestored,
+		FromStatus: domain.StatusArchived,
+		ToStatus:   to,
+		Event:      "Application restored to status: " + string(to),
+	}
+}
+
+// statusBeforeArchive returns the status an application had when it was last
+// archived, defaulting to Applied.
+func statusBeforeArchive(history []domain.HistoryEvent) domain.ApplicationStatus {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Type == domain.EventArchived {
+			if from := history[i].FromStatus; from != "" && from != domain.StatusArchived {
+				return from
+			}
+			break
+		}
+	}
+	return domain.StatusApplied
+}
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"joblog/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxImportRows           = 1000
+	maxImportFileNameLength = 255
+)
+
+// importDateFormats are the date patterns an import understands, in the order they are
+// tried when detecting the format. Ambiguous files like "03/04/2025" are read as US dates
+// unless some date only makes sense day-first.
+var importDateFormats = []struct {
+	name, layout string
+}{
+	{"YYYY-MM-DD", "2006-01-02"},
+	{"YYYY/MM/DD", "2006/1/2"},
+	{"MM/DD/YYYY", "1/2/2006"},
+	{"DD/MM/YYYY", "2/1/2006"},
+	{"DD.MM.YYYY", "2.1.2006"},
+	{"MM-DD-YYYY", "1-2-2006"},
+	{"DD-MM-YYYY", "2-1-2006"},
+	{"MM/DD/YY", "1/2/06"},
+	{"DD/MM/YY", "2/1/06"},
+	{"MMM D, YYYY", "Jan 2, 2006"},
+	{"MMMM D, YYYY", "January 2, 2006"},
+	{"D MMM YYYY", "2 Jan 2006"},
+	{"D MMMM YYYY", "2 January 2006"},
+}
+
+// importColumnNames are the headers each field is looked up by when the mapping leaves it out.
+var importColumnNames = map[string][]string{
+	"company":  {"company", "company name", "employer", "organization"},
+	"role":     {"role", "position", "title", "job title", "job"},
+	"date":     {"date", "applied", "applied on", "date applied", "application date"},
+	"status":   {"status", "stage", "state"},
+	"jobUrl":   {"job url", "url", "link", "job link", "posting"},
+	"location": {"location", "city"},
+	"notes":    {"notes", "note", "comments"},
+}
+
+// statusSynonyms maps free-text statuses seen in spreadsheets to the default workflow's statuses.
+var statusSynonyms = map[string]domain.ApplicationStatus{
+	"":               domain.StatusApplied,
+	"submitted":      domain.StatusApplied,
+	"sent":           domain.StatusApplied,
+	"pending":        domain.StatusApplied,
+	"waiting":        domain.StatusApplied,
+	"screen":         domain.StatusPhoneScreen,
+	"screening":      domain.StatusPhoneScreen,
+	"phone":          domain.StatusPhoneScreen,
+	"recruiter":      domain.StatusPhoneScreen,
+	"take home":      domain.StatusTakeHome,
+	"assignment":     domain.StatusTakeHome,
+	"interview":      domain.StatusInterviewing,
+	"interviews":     domain.StatusInterviewing,
+	"in progress":    domain.StatusInterviewing,
+	"on site":        domain.StatusOnsite,
+	"final round":    domain.StatusOnsite,
+	"offered":        domain.StatusOffer,
+	"accepted":       domain.StatusOffer,
+	"rejection":      domain.StatusRejected,
+	"declined":       domain.StatusRejected,
+	"no":             domain.StatusRejected,
+	"not selected":   domain.StatusRejected,
+	"withdrew":       domain.StatusWithdrawn,
+	"no response":    domain.StatusGhosted,
+	"no reply":       domain.StatusGhosted,
+	"never heard":    domain.StatusGhosted,
+	"not interested": domain.StatusWithdrawn,
+}
+
+// Import reads applications from a CSV file. Every row is parsed and validated like
+// Create would, and checked for duplicates against the user's applications and the rows
+// above it. Unless this is a dry run or some row is invalid, the valid rows are then
+// created in one transaction; duplicates are skipped unless forced.
+func (s *ApplicationService) Import(ctx context.Context, userID string, req domain.ImportRequest) (*domain.ImportResult, error) {
+	header, records, lines, err := readImportCSV(req.CSV)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := importColumns(header, req.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	value := func(record []string, field string) string {
+		if i, ok := columns[field]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	dates := make([]string, len(records))
+	for i, record := range records {
+		dates[i] = value(record, "date")
+	}
+	dateFormat, layout, err := importDateFormat(req.DateFormat, dates)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.workflows.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	source := strings.TrimSpace(req.FileName)
+	if utf8.RuneCountInString(source) > maxImportFileNameLength {
+		return nil, fmt.Errorf("%w: fileName must be at most %d characters", domain.ErrInvalidInput, maxImportFileNameLength)
+	}
+	if source == "" {
+		source = "CSV"
+	}
+
+	result := &domain.ImportResult{DryRun: req.DryRun, DateFormat: dateFormat, Rows: make([]domain.ImportRow, len(records))}
+	var apps []*domain.Application
+	lineOf := make(map[string]int) // Pending application ID -> line
+	// The user's applications by normalized company name, fetched once per company
+	stored := make(map[string][]*domain.Application)
+	invalid := false
+
+	for i, record := range records {
+		row := domain.ImportRow{
+			Line:    lines[i],
+			Company: value(record, "company"),
+			Role:    value(record, "role"),
+			Result:  domain.ImportValid,
+		}
+
+		if date, err := time.Parse(layout, dates[i]); err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("date %q is not in %s format", dates[i], dateFormat))
+		} else {
+			row.Date = date.Format("2006-01-02")
+		}
+		rawStatus := value(record, "status")
+		status, ok := importStatus(rawStatus, workflow, req.StatusMap)
+		if !ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("unknown status %q; map it with statusMap", rawStatus))
+		}
+		row.Status = status
+
+		var app *domain.Application
+		if len(row.Errors) == 0 {
+			newApp := domain.NewApplication{Company: row.Company, Role: row.Role, Date: row.Date, Status: row.Status}
+			newApp.JobURL = value(record, "jobUrl")
+			newApp.Location = value(record, "location")
+			if app, err = s.newApplication(ctx, userID, workflow, newApp); err != nil {
+				if !errors.Is(err, domain.ErrInvalidInput) && !errors.Is(err, domain.ErrInvalidTransition) {
+					return nil, err
+				}
+				row.Errors = append(row.Errors, err.Error())
+			}
+		}
+		if len(row.Errors) > 0 {
+			row.Result = domain.ImportInvalid
+			result.Rows[i] = row
+			invalid = true
+			continue
+		}
+
+		company := domain.NormalizeCompanyName(app.Company)
+		candidates, ok := stored[company]
+		if !ok {
+			if candidates, err = s.repo.FindByCompanyName(ctx, userID, company); err != nil {
+				return nil, err
+			}
+			stored[company] = candidates
+		}
+		ids := s.matchDuplicates(app, candidates, apps)
+		for _, id := range ids {
+			if line, ok := lineOf[id]; ok {
+				id = fmt.Sprintf("line %d", line)
+			}
+			row.Duplicates = append(row.Duplicates, id)
+		}
+		if len(ids) > 0 && !req.Force {
+			row.Result = domain.ImportDuplicate
+			result.Rows[i] = row
+			result.Skipped++
+			continue
+		}
+
+		if content := value(record, "notes"); content != "" {
+			app.Notes = append(app.Notes, domain.Note{ID: uuid.NewString(), Content: content, CreatedAt: time.Now()})
+		}
+		app.History = []domain.HistoryEvent{importedEvent(app.Status, source)}
+		row.ApplicationID = app.ID
+		apps = append(apps, app)
+		lineOf[app.ID] = row.Line
+		result.Rows[i] = row
+	}
+
+	if req.DryRun || invalid {
+		// Nothing was stored, so don't hand out IDs that don't exist
+		for i := range result.Rows {
+			result.Rows[i].ApplicationID = ""
+		}
+		return result, nil
+	}
+	if err := s.repo.ApplyBatch(ctx, domain.ApplicationBatch{Creates: apps}); err != nil {
+		return nil, err
+	}
+	result.Imported = true
+	result.Created = len(apps)
+	return result, nil
+}
+
+// readImportCSV parses the file, returning the header, the non-blank rows and the line
+// each row starts on. The delimiter is whichever of comma, semicolon or tab the header uses most.
+func readImportCSV(data string) ([]string, [][]string, []int, error) {
+	data = strings.TrimPrefix(data, "\ufeff") // Excel's byte order mark
+	firstLine, _, _ := strings.Cut(data, "\n")
+
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	for _, delimiter := range []rune{';', '\t'} {
+		if strings.Count(firstLine, string(delimiter)) > strings.Count(firstLine, string(reader.Comma)) {
+			reader.Comma = delimiter
+		}
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, nil, fmt.Errorf("%w: the CSV file is empty", domain.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: could not read CSV: %v", domain.ErrInvalidInput, err)
+	}
+
+	var records [][]string
+	var lines []int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: could not read CSV: %v", domain.ErrInvalidInput, err)
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		if len(records) == maxImportRows {
+			return nil, nil, nil, fmt.Errorf("%w: at most %d rows can be imported at once", domain.ErrInvalidInput, maxImportRows)
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+	if len(records) == 0 {
+		return nil, nil, nil, fmt.Errorf("%w: the CSV file has no rows below the header", domain.ErrInvalidInput)
+	}
+	return header, records, lines, nil
+}
+
+// importColumns finds the column index of each field, from the mapping or the usual header names.
+func importColumns(header []string, mapping domain.ImportMapping) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, taken := index[key]; !taken {
+			index[key] = i
+		}
+	}
+
+	mapped := map[string]string{
+		"company":  mapping.Company,
+		"role":     mapping.Role,
+		"date":     mapping.Date,
+		"status":   mapping.Status,
+		"jobUrl":   mapping.JobURL,
+		"location": mapping.Location,
+		"notes":    mapping.Notes,
+	}
+	columns := make(map[string]int)
+	for field, name := range mapped {
+		if name = strings.TrimSpace(name); name != "" {
+			i, ok := index[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("%w: mapping.%s names column %q, which is not in the header", domain.ErrInvalidInput, field, name)
+			}
+			columns[field] = i
+			continue
+		}
+		for _, candidate := range importColumnNames[field] {
+			if i, ok := index[candidate]; ok {
+				columns[field] = i
+				break
+			}
+		}
+	}
+
+	for _, field := range []string{"company", "date"} {
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("%w: no %s column found; set mapping.%s to its header", domain.ErrInvalidInput, field, field)
+		}
+	}
+	return columns, nil
+}
+
+// importDateFormat resolves the requested date format, or detects the one that reads the
+// most of the given dates.
+func importDateFormat(requested string, dates []string) (string, string, error) {
+	if requested = strings.TrimSpace(requested); requested != "" {
+		names := make([]string, len(importDateFormats))
+		for i, format := range importDateFormats {
+			if strings.EqualFold(format.name, requested) {
+				return format.name, format.layout, nil
+			}
+			names[i] = format.name
+		}
+		return "", "", fmt.Errorf("%w: unknown date format %q; use one of %s", domain.ErrInvalidInput, requested, strings.Join(names, ", "))
+	}
+
+	best, bestCount := importDateFormats[0], -1
+	for _, format := range importDateFormats {
+		count := 0
+		for _, date := range dates {
+			if _, err := time.Parse(format.layout, date); err == nil {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = format, count
+		}
+	}
+	return best.name, best.layout, nil
+}
+
+// importStatus maps a free-text status to one of the workflow's statuses. An empty status
+// means Applied.
+func importStatus(raw string, workflow *domain.Workflow, statusMap map[string]domain.ApplicationStatus) (domain.ApplicationStatus, bool) {
+	for text, status := range statusMap {
+		if strings.EqualFold(strings.TrimSpace(text), raw) {
+			return status, true
+		}
+	}
+	for _, status := range workflow.Statuses {
+		if strings.EqualFold(string(status), raw) {
+			return status, true
+		}
+	}
+	normalized := strings.Join(strings.Fields(strings.ToLower(strings.NewReplacer("-", " ", "_", " ").Replace(raw))), " ")
+	for _, status := range workflow.Statuses {
+		if strings.EqualFold(strings.ReplaceAll(string(status), "-", " "), normalized) {
+			return status, true
+		}
+	}
+	if status, ok := statusSynonyms[normalized]; ok {
+		return status, true
+	}
+	return domain.ApplicationStatus(raw), false
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"joblog/internal/core/domain"
+)
+
+func TestImportDateFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		requested  string
+		dates      []string
+		wantFormat string
+		wantLayout string
+	}{
+		{"requested", "DD/MM/YYYY", []string{"2025-03-04"}, "DD/MM/YYYY", "2/1/2006"},
+		{"requested ignores case", " mmm d, yyyy ", nil, "MMM D, YYYY", "Jan 2, 2006"},
+		{"ISO", "", []string{"2025-03-04", "2025-12-31"}, "YYYY-MM-DD", "2006-01-02"},
+		{"US", "", []string{"03/04/2025", "12/31/2025"}, "MM/DD/YYYY", "1/2/2006"},
+		{"ambiguous reads as US", "", []string{"03/04/2025", "5/6/2025"}, "MM/DD/YYYY", "1/2/2006"},
+		{"day first when some date needs it", "", []string{"03/04/2025", "31/12/2025"}, "DD/MM/YYYY", "2/1/2006"},
+		{"dotted", "", []string{"31.12.2025", "1.2.2025"}, "DD.MM.YYYY", "2.1.2006"},
+		{"two digit years", "", []string{"03/04/25", "12/31/25"}, "MM/DD/YY", "1/2/06"},
+		{"month names", "", []string{"Jan 5, 2025", "Mar 10, 2025"}, "MMM D, YYYY", "Jan 2, 2006"},
+		{"long month names", "", []string{"5 January 2025", "10 March 2025"}, "D MMMM YYYY", "2 January 2006"},
+		{"most dates win", "", []string{"2025-03-04", "04/03/2025", "05/03/2025", "oops"}, "MM/DD/YYYY", "1/2/2006"},
+		{"nothing parses", "", []string{"", "soon"}, "YYYY-MM-DD", "2006-01-02"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, layout, err := importDateFormat(tt.requested, tt.dates)
+			if err != nil {
+				t.Fatalf("importDateFormat(%q, %q) = %v", tt.requested, tt.dates, err)
+			}
+			if format != tt.wantFormat || layout != tt.wantLayout {
+				t.Errorf("importDateFormat(%q, %q) = %q, %q; want %q, %q", tt.requested, tt.dates, format, layout, tt.wantFormat, tt.wantLayout)
+			}
+		})
+	}
+
+	if _, _, err := importDateFormat("YYYYMMDD", nil); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("importDateFormat with an unknown format = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestImportStatus(t *testing.T) {
+	custom := &domain.Workflow{Statuses: []domain.ApplicationStatus{"Sourced", domain.StatusApplied, "On Hold"}}
+
+	tests := []struct {
+		name      string
+		raw       string
+		workflow  *domain.Workflow
+		statusMap map[string]domain.ApplicationStatus
+		want      domain.ApplicationStatus
+		wantOK    bool
+	}{
+		{"exact", "Interviewing", domain.DefaultWorkflow(), nil, domain.StatusInterviewing, true},
+		{"case", "applied", domain.DefaultWorkflow(), nil, domain.StatusApplied, true},
+		{"dash for space", "phone-screen", domain.DefaultWorkflow(), nil, domain.StatusPhoneScreen, true},
+		{"underscore for dash", "take_home", domain.DefaultWorkflow(), nil, domain.StatusTakeHome, true},
+		{"empty means applied", "", domain.DefaultWorkflow(), nil, domain.StatusApplied, true},
+		{"synonym", "Rejection", domain.DefaultWorkflow(), nil, domain.StatusRejected, true},
+		{"synonym with extra spaces", "No   Response", domain.DefaultWorkflow(), nil, domain.StatusGhosted, true},
+		{"custom status", "on hold", custom, nil, "On Hold", true},
+		{"status map", "Hired", domain.DefaultWorkflow(), map[string]domain.ApplicationStatus{"hired": domain.StatusOffer}, domain.StatusOffer, true},
+		{"status map wins", "Applied", domain.DefaultWorkflow(), map[string]domain.ApplicationStatus{" applied ": domain.StatusInterviewing}, domain.StatusInterviewing, true},
+		{"unknown", "Maybe", domain.DefaultWorkflow(), nil, "Maybe", false},
+		{"not in custom workflow", "Offer", custom, nil, "Offer", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := importStatus(tt.raw, tt.workflow, tt.statusMap)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("importStatus(%q) = %q, %v; want %q, %v", tt.raw, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
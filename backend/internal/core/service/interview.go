@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"joblog/internal/core/domain"
+	"joblog/pkg/ical"
+
+	"github.com/google/uuid"
+)
+
+type InterviewService struct {
+	repo       domain.InterviewRepository
+	appService *ApplicationService
+}
+
+func NewInterviewService(repo domain.InterviewRepository, appService *ApplicationService) *InterviewService {
+	return &InterviewService{repo: repo, appService: appService}
+}
+
+// Schedule adds an interview round to an application. An application still in
+// Applied is moved to Interviewing, if the user's workflow allows it. Archived
+// applications have to be restored first.
+func (s *InterviewService) Schedule(ctx context.Context, userID, appID string, newInterview domain.NewInterview) (*domain.Interview, error) {
+	app, err := s.appService.GetByID(ctx, userID, appID)
+	if err != nil {
+		return nil, err
+	}
+	if app.Status == domain.StatusArchived {
+		return nil, fmt.Errorf("%w: application is archived", domain.ErrConflict)
+	}
+
+	now := time.Now()
+	interview := &domain.Interview{
+		ID:              uuid.NewString(),
+		ApplicationID:   app.ID,
+		UserID:          userID,
+		RoundName:       strings.TrimSpace(newInterview.RoundName),
+		Type:            newInterview.Type,
+		ScheduledAt:     newInterview.ScheduledAt,
+		Timezone:        newInterview.Timezone,
+		DurationMinutes: newInterview.DurationMinutes,
+		Interviewers:    newInterview.Interviewers,
+		Location:        newInterview.Location,
+		MeetingURL:      newInterview.MeetingURL,
+		Outcome:         domain.OutcomePending,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if interview.Timezone == "" {
+		interview.Timezone = "UTC"
+	}
+	if interview.DurationMinutes == 0 {
+		interview.DurationMinutes = 60
+	}
+	if interview.Interviewers == nil {
+		interview.Interviewers = []string{}
+	}
+	if err := validateInterview(interview); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, interview); err != nil {
+		return nil, err
+	}
+
+	if err := s.appService.advanceForInterview(ctx, userID, app, interview); err != nil {
+		// Undo the interview so a retry doesn't leave a duplicate behind
+		if delErr := s.repo.Delete(ctx, interview.ID); delErr != nil {
+			log.Println("[InterviewService.Schedule] Error:", delErr)
+		}
+		return nil, err
+	}
+
+	return interview, nil
+}
+
+func (s *InterviewService) ListByApplication(ctx context.Context, userID, appID string) ([]*domain.Interview, error) {
+	if _, err := s.appService.GetByID(ctx, userID, appID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByApplicationID(ctx, appID)
+}
+
+// GetByID returns an interview after checking it belongs to the user's application.
+func (s *InterviewService) GetByID(ctx context.Context, userID, appID, interviewID string) (*domain.Interview, error) {
+	interview, err := s.repo.GetByID(ctx, interviewID)
+	if err != nil {
+		return nil, err
+	}
+	if interview.UserID != userID || interview.ApplicationID != appID {
+		return nil, fmt.Errorf("interview %w", domain.ErrNotFound)
+	}
+	return interview, nil
+}
+
+func (s *InterviewService) Update(ctx context.Context, userID, appID, interviewID string, updateData domain.InterviewUpdate) (*domain.Interview, error) {
+	current, err := s.GetByID(ctx, userID, appID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Work on a copy so a failed validation leaves the stored interview untouched
+	interview := *current
+	if updateData.RoundName != nil {
+		interview.RoundName = strings.TrimSpace(*updateData.RoundName)
+	}
+	if updateData.Type != nil {
+		interview.Type = *updateData.Type
+	}
+	if updateData.ScheduledAt != nil {
+		interview.ScheduledAt = *updateData.ScheduledAt
+	}
+	if updateData.Timezone != nil {
+		interview.Timezone = *updateData.Timezone
+	}
+	if updateData.DurationMinutes != nil {
+		interview.DurationMinutes = *updateData.DurationMinutes
+	}
+	if updateData.Interviewers != nil {
+		interview.Interviewers = *updateData.Interviewers
+	}
+	if updateData.Location != nil {
+		interview.Location = *updateData.Location
+	}
+	if updateData.MeetingURL != nil {
+		interview.MeetingURL = *updateData.MeetingURL
+	}
+	if updateData.Outcome != nil {
+		interview.Outcome = *updateData.Outcome
+	}
+	if updateData.Feedback != nil {
+		interview.Feedback = *updateData.Feedback
+	}
+	interview.UpdatedAt = time.Now()
+
+	if err := validateInterview(&interview); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, &interview); err != nil {
+		return nil, err
+	}
+	return &interview, nil
+}
+
+func (s *InterviewService) Delete(ctx context.Context, userID, appID, interviewID string) error {
+	if _, err := s.GetByID(ctx, userID, appID, interviewID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, interviewID)
+}
+
+func validateInterview(interview *domain.Interview) error {
+	switch interview.Type {
+	case domain.InterviewPhone, domain.InterviewVideo, domain.InterviewOnsite, domain.InterviewTechnical,
+		domain.InterviewBehavioral, domain.InterviewTakeHome, domain.InterviewOther:
+	default:
+		return fmt.Errorf("%w: unknown interview type %q", domain.ErrInvalidInput, interview.Type)
+	}
+
+	switch interview.Outcome {
+	case domain.OutcomePending, domain.OutcomePassed, domain.OutcomeFailed, domain.OutcomeCancelled:
+	default:
+		return fmt.Errorf("%w: unknown interview outcome %q", domain.ErrInvalidInput, interview.Outcome)
+	}
+
+	if interview.RoundName == "" {
+		return fmt.Errorf("%w: round name is required", domain.ErrInvalidInput)
+	}
+	if interview.ScheduledAt.IsZero() {
+		return fmt.Errorf("%w: scheduled time is required", domain.ErrInvalidInput)
+	}
+	if _, err := time.LoadLocation(interview.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", domain.ErrInvalidInput, interview.Timezone)
+	}
+	if interview.DurationMinutes <= 0 || interview.DurationMinutes > 24*60 {
+		return fmt.Errorf("%w: duration must be between 1 and 1440 minutes", domain.ErrInvalidInput)
+	}
+	if interview.MeetingURL != "" && !isHTTPURL(interview.MeetingURL) {
+		return fmt.Errorf("%w: meeting URL must be an http(s) URL", domain.ErrInvalidInput)
+	}
+	return nil
+}
+
+// calendarLookback keeps recent interviews in the feed so clients don't drop them the moment they start.
+const calendarLookback = 30 * 24 * time.Hour
+
+// CalendarEvents implements CalendarEventSource.
+func (s *InterviewService) CalendarEvents(ctx context.Context, userID string) ([]ical.Event, error) {
+	interviews, err := s.repo.ListByUserID(ctx, userID, time.Now().Add(-calendarLookback))
+	if err != nil {
+		return nil, err
+	}
+
+	apps := make(map[string]*domain.Application)
+	events := make([]ical.Event, 0, len(interviews))
+	for _, interview := range interviews {
+		app, ok := apps[interview.ApplicationID]
+		if !ok {
+			if app, err = s.appService.GetByID(ctx, userID, interview.ApplicationID); err != nil {
+				return nil, err
+			}
+			apps[interview.ApplicationID] = app
+		}
+
+		description := []string{fmt.Sprintf("%s interview for %s at %s", interview.Type, app.Role, app.Company)}
+		if len(interview.Interviewers) > 0 {
+			description = append(description, "Interviewers: "+strings.Join(interview.Interviewers, ", "))
+		}
+		if interview.MeetingURL != "" {
+			description = append(description, "Join: "+interview.MeetingURL)
+		}
+
+		location := interview.Location
+		if location == "" {
+			location = interview.MeetingURL
+		}
+
+		events = append(events, ical.Event{
+			UID:          "interview-" + interview.ID + "@joblog",
+			Summary:      fmt.Sprintf("%s: %s", app.Company, interview.RoundName),
+			Description:  strings.Join(description, "\n"),
+			Location:     location,
+			URL:          interview.MeetingURL,
+			Start:        interview.ScheduledAt,
+			End:          interview.ScheduledAt.Add(time.Duration(interview.DurationMinutes) * time.Minute),
+			LastModified: interview.UpdatedAt,
+			Cancelled:    interview.Outcome == domain.OutcomeCancelled,
+		})
+	}
+	return events, nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"joblog/internal/core/domain"
+)
+
+// applyJobPostingUpdate copies the fields set in the update onto the posting.
+func applyJobPostingUpdate(posting *domain.JobPosting, updateData domain.ApplicationUpdate) {
+	if updateData.JobURL != nil {
+		posting.JobURL = *updateData.JobURL
+	}
+	if updateData.Location != nil {
+		posting.Location = *updateData.Location
+	}
+	if updateData.WorkMode != nil {
+		posting.WorkMode = *updateData.WorkMode
+	}
+	if updateData.SalaryMin != nil {
+		salary := *updateData.SalaryMin
+		posting.SalaryMin = &salary
+	}
+	if updateData.SalaryMax != nil {
+		salary := *updateData.SalaryMax
+		posting.SalaryMax = &salary
+	}
+	if updateData.SalaryCurrency != nil {
+		posting.SalaryCurrency = *updateData.SalaryCurrency
+	}
+	if updateData.EmploymentType != nil {
+		posting.EmploymentType = *updateData.EmploymentType
+	}
+	if updateData.Source != nil {
+		posting.Source = *updateData.Source
+	}
+	if updateData.Priority != nil {
+		posting.Priority = *updateData.Priority
+	}
+	if updateData.JobDescription != nil {
+		posting.JobDescription = *updateData.JobDescription
+	}
+}
+
+// normalizeJobPosting trims the text fields, upper-cases the currency and treats a
+// zero salary bound as unset.
+func normalizeJobPosting(posting *domain.JobPosting) {
+	posting.JobURL = strings.TrimSpace(posting.JobURL)
+	posting.Location = strings.TrimSpace(posting.Location)
+	posting.SalaryCurrency = strings.ToUpper(strings.TrimSpace(posting.SalaryCurrency))
+	posting.JobDescription = strings.TrimSpace(posting.JobDescription)
+	if posting.SalaryMin != nil && *posting.SalaryMin == 0 {
+		posting.SalaryMin = nil
+	}
+	if posting.SalaryMax != nil && *posting.SalaryMax == 0 {
+		posting.SalaryMax = nil
+	}
+}
+
+func validateJobPosting(posting *domain.JobPosting) error {
+	if posting.JobURL != "" && !isHTTPURL(posting.JobURL) {
+		return fmt.Errorf("%w: job URL must be an http(s) URL", domain.ErrInvalidInput)
+	}
+
+	switch posting.WorkMode {
+	case "", domain.WorkModeRemote, domain.WorkModeHybrid, domain.WorkModeOnsite:
+	default:
+		return fmt.Errorf("%w: unknown work mode %q", domain.ErrInvalidInput, posting.WorkMode)
+	}
+	switch posting.EmploymentType {
+	case "", domain.EmploymentFullTime, domain.EmploymentPartTime, domain.EmploymentContract,
+		domain.EmploymentInternship, domain.EmploymentTemporary:
+	default:
+		return fmt.Errorf("%w: unknown employment type %q", domain.ErrInvalidInput, posting.EmploymentType)
+	}
+	switch posting.Source {
+	case "", domain.SourceLinkedIn, domain.SourceReferral, domain.SourceCompanySite,
+		domain.SourceJobBoard, domain.SourceRecruiter, domain.SourceOther:
+	default:
+		return fmt.Errorf("%w: unknown source %q", domain.ErrInvalidInput, posting.Source)
+	}
+	switch posting.Priority {
+	case "", domain.PriorityLow, domain.PriorityMedium, domain.PriorityHigh:
+	default:
+		return fmt.Errorf("%w: unknown priority %q", domain.ErrInvalidInput, posting.Priority)
+	}
+
+	if (posting.SalaryMin != nil && *posting.SalaryMin < 0) || (posting.SalaryMax != nil && *posting.SalaryMax < 0) {
+		return fmt.Errorf("%w: salary cannot be negative", domain.ErrInvalidInput)
+	}
+	if posting.SalaryMin != nil && posting.SalaryMax != nil && *posting.SalaryMin > *posting.SalaryMax {
+		return fmt.Errorf("%w: salaryMin cannot be greater than salaryMax", domain.ErrInvalidInput)
+	}
+	hasSalary := posting.SalaryMin != nil || posting.SalaryMax != nil
+	if hasSalary && posting.SalaryCurrency == "" {
+		return fmt.Errorf("%w: salaryCurrency is required with a salary", domain.ErrInvalidInput)
+	}
+	if posting.SalaryCurrency != "" && !isCurrencyCode(posting.SalaryCurrency) {
+		return fmt.Errorf("%w: %q is not an ISO 4217 currency code", domain.ErrInvalidInput, posting.SalaryCurrency)
+	}
+	return nil
+}
+
+// jobPostingChanges returns the JSON names of the fields that differ, for the history.
+func jobPostingChanges(before, after *domain.JobPosting) []string {
+	var changed []string
+	add := func(field string, differs bool) {
+		if differs {
+			changed = append(changed, field)
+		}
+	}
+	add("jobUrl", before.JobURL != after.JobURL)
+	add("location", before.Location != after.Location)
+	add("workMode", before.WorkMode != after.WorkMode)
+	add("salaryMin", !equalInts(before.SalaryMin, after.SalaryMin))
+	add("salaryMax", !equalInts(before.SalaryMax, after.SalaryMax))
+	add("salaryCurrency", before.SalaryCurrency != after.SalaryCurrency)
+	add("employmentType", before.EmploymentType != after.EmploymentType)
+	add("source", before.Source != after.Source)
+	add("priority", before.Priority != after.Priority)
+	add("jobDescription", before.JobDescription != after.JobDescription)
+	return changed
+}
+
+func equalInts(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"joblog/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// LoginPolicy controls how hard it is to guess passwords: how many logins a client may
+// attempt, and how long a username is locked after failing repeatedly.
+type LoginPolicy struct {
+	// Window is the sliding window the rate limits apply to.
+	Window time.Duration
+	// IPLimit and UsernameLimit are the most login attempts per window from one IP
+	// address and for one username.
+	IPLimit       int
+	UsernameLimit int
+	// ForgotPasswordLimit is the most password reset emails one IP address may ask for
+	// per window. It is counted apart from logins, so neither can use up the other.
+	ForgotPasswordLimit int
+
+	// LockoutThreshold is the number of consecutive failures that locks a username.
+	// The first lockout lasts LockoutBase, and each further failure doubles it, up
+	// to LockoutMax.
+	LockoutThreshold int
+	LockoutBase      time.Duration
+	LockoutMax       time.Duration
+	// FailureReset is how long after the last failure the count starts over.
+	FailureReset time.Duration
+
+	// AuditRetention is how long failed logins are kept in the audit log.
+	AuditRetention time.Duration
+}
+
+func DefaultLoginPolicy() LoginPolicy {
+	return LoginPolicy{
+		Window:              15 * time.Minute,
+		IPLimit:             50,
+		UsernameLimit:       10,
+		ForgotPasswordLimit: 10,
+		LockoutThreshold:    5,
+		LockoutBase:         time.Minute,
+		LockoutMax:          time.Hour,
+		FailureReset:        24 * time.Hour,
+		AuditRetention:      90 * 24 * time.Hour,
+	}
+}
+
+// lockoutDuration is how long a username with this many consecutive failures is locked.
+func (p LoginPolicy) lockoutDuration(failures int) time.Duration {
+	if failures < p.LockoutThreshold {
+		return 0
+	}
+	d := p.LockoutBase
+	for i := p.LockoutThreshold; i < failures && d < p.LockoutMax; i++ {
+		d *= 2
+	}
+	return min(d, p.LockoutMax)
+}
+
+// LoginGuard throttles logins. Rate limits are applied per request by middleware, before
+// the password is even checked; lockouts are applied by AuthService.Login.
+type LoginGuard struct {
+	attempts   domain.LoginAttemptRepository
+	rateLimits domain.RateLimitRepository
+	policy     LoginPolicy
+}
+
+func NewLoginGuard(attempts domain.LoginAttemptRepository, rateLimits domain.RateLimitRepository, policy LoginPolicy) *LoginGuard {
+	return &LoginGuard{attempts: attempts, rateLimits: rateLimits, policy: policy}
+}
+
+// NormalizeUsername is the form usernames are rate limited and locked out by, so changing
+// the case doesn't get around them.
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// IPLimiter limits login attempts per client IP address.
+func (g *LoginGuard) IPLimiter() *RateLimiter {
+	return &RateLimiter{repo: g.rateLimits, prefix: "login:ip:", limit: g.policy.IPLimit, window: g.policy.Window}
+}
+
+// ForgotPasswordLimiter limits password reset requests per client IP address.
+func (g *LoginGuard) ForgotPasswordLimiter() *RateLimiter {
+	return &RateLimiter{repo: g.rateLimits, prefix: "forgot:ip:", limit: g.policy.ForgotPasswordLimit, window: g.policy.Window}
+}
+
+const usernameLimitPrefix = "login:user:"
+
+// UsernameLimiter limits login attempts per username, whichever address they come from.
+func (g *LoginGuard) UsernameLimiter() *RateLimiter {
+	return &RateLimiter{repo: g.rateLimits, prefix: usernameLimitPrefix, normalize: NormalizeUsername,
+		limit: g.policy.UsernameLimit, window: g.policy.Window}
+}
+
+// CheckLockout returns a *domain.RateLimitError while the username is locked out.
+func (g *LoginGuard) CheckLockout(ctx context.Context, username string) error {
+	lockout, err := g.attempts.GetLockout(ctx, username)
+	if err != nil {
+		return err
+	}
+	if lockout == nil || lockout.LockedUntil == nil {
+		return nil
+	}
+	if wait := time.Until(*lockout.LockedUntil); wait > 0 {
+		return &domain.RateLimitError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// RecordFailure writes the failed login to the audit log and, unless it was refused for
+// the username being locked already, counts it towards a lockout.
+func (g *LoginGuard) RecordFailure(ctx context.Context, username, userID string, reason domain.LoginFailureReason, client domain.SessionClient) error {
+	now := time.Now()
+	err := g.attempts.Record(ctx, &domain.LoginAttempt{
+		ID:        uuid.NewString(),
+		Username:  username,
+		UserID:    userID,
+		IP:        client.IP,
+		UserAgent: client.UserAgent,
+		Reason:    reason,
+		CreatedAt: now,
+	})
+	if err != nil || reason == domain.LoginLockedOut {
+		return err
+	}
+
+	failures, err := g.attempts.AddFailure(ctx, username, now, now.Add(-g.policy.FailureReset))
+	if err != nil {
+		return err
+	}
+	if d := g.policy.lockoutDuration(failures); d > 0 {
+		log.Printf("[LoginGuard] Locking username %q for %s after %d failed logins", username, d, failures)
+		return g.attempts.Lock(ctx, username, now.Add(d))
+	}
+	return nil
+}
+
+// RecordSuccess resets the username's failure count.
+func (g *LoginGuard) RecordSuccess(ctx context.Context, username string) error {
+	return g.attempts.ClearFailures(ctx, username)
+}
+
+// Forget drops the username's failure count, lockout and rate limit, so whoever registers
+// it after the account is deleted starts with a clean slate. The audit log is kept.
+func (g *LoginGuard) Forget(ctx context.Context, username string) error {
+	username = NormalizeUsername(username)
+	if err := g.attempts.ClearFailures(ctx, username); err != nil {
+		return err
+	}
+	return g.rateLimits.DeleteKey(ctx, usernameLimitPrefix+username)
+}
+
+// Prune drops expired rate limit counters and failure counts, and audit records past
+// their retention.
+func (g *LoginGuard) Prune(ctx context.Context, now time.Time) error {
+	// A window's count is still needed while the window after it is current
+	if err := g.rateLimits.DeleteBefore(ctx, now.Add(-2*g.policy.Window)); err != nil {
+		return err
+	}
+	return g.attempts.Prune(ctx, now.Add(-g.policy.AuditRetention), now.Add(-g.policy.FailureReset))
+}
+
+// RateLimiter is a sliding-window rate limiter. It keeps a hit count per fixed window and
+// estimates the hits in the sliding window as the current window's count plus the part
+// of the previous window's count that the sliding window still overlaps.
+type RateLimiter struct {
+	repo      domain.RateLimitRepository
+	prefix    string // Keeps the keys of different limiters apart
+	normalize func(string) string
+	limit     int
+	window    time.Duration
+}
+
+// Allow counts a hit for the key and reports whether it is within the limit. If not, it
+// also returns how long until the next hit would be allowed.
+func (l *RateLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	return l.allowAt(ctx, key, time.Now())
+}
+
+func (l *RateLimiter) allowAt(ctx context.Context, key string, now time.Time) (bool, time.Duration, error) {
+	if l.normalize != nil {
+		key = l.normalize(key)
+	}
+	windowStart := now.Truncate(l.window)
+	current, previous, err := l.repo.Hit(ctx, l.prefix+key, windowStart, windowStart.Add(-l.window))
+	if err != nil {
+		return false, 0, fmt.Errorf("rate limiter: %w", err)
+	}
+
+	elapsed := now.Sub(windowStart)
+	overlap := float64(l.window-elapsed) / float64(l.window)
+	if float64(previous)*overlap+float64(current) <= float64(l.limit) {
+		return true, 0, nil
+	}
+
+	// The estimate drops as the previous window slides out; if the current window
+	// alone is over the limit, it takes until the next window starts
+	retryAfter := l.window - elapsed
+	if current <= l.limit && previous > 0 {
+		needed := float64(l.limit-current) / float64(previous) // Largest overlap that fits
+		retryAfter = time.Duration((overlap - needed) * float64(l.window))
+	}
+	return false, max(retryAfter, time.Second), nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeRateLimits returns fixed counts and records the last hit.
+type fakeRateLimits struct {
+	current, previous          int
+	err                        error
+	key                        string
+	windowStart, previousStart time.Time
+}
+
+func (f *fakeRateLimits) Hit(ctx context.Context, key string, windowStart, previousStart time.Time) (int, int, error) {
+	f.key, f.windowStart, f.previousStart = key, windowStart, previousStart
+	return f.current, f.previous, f.err
+}
+
+func (f *fakeRateLimits) DeleteBefore(ctx context.Context, before time.Time) error { return nil }
+
+func (f *fakeRateLimits) DeleteKey(ctx context.Context, key string) error { return nil }
+
+func TestRateLimiterAllow(t *testing.T) {
+	windowStart := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	quarter := windowStart.Add(150 * time.Second) // A quarter into the window; 3/4 of the previous one still counts
+
+	tests := []struct {
+		name              string
+		now               time.Time
+		current, previous int
+		wantAllowed       bool
+		wantRetryAfter    time.Duration
+	}{
+		{"first hit", quarter, 1, 0, true, 0},
+		{"at the limit", quarter, 10, 0, true, 0},
+		{"current window over the limit", quarter, 11, 0, false, 450 * time.Second},
+		{"previous window still counts", quarter, 4, 8, true, 0},
+		{"over with the previous window", quarter, 5, 8, false, 75 * time.Second},
+		{"current window over despite previous", quarter, 11, 8, false, 450 * time.Second},
+		{"previous window slid out", windowStart.Add(225 * time.Second), 5, 8, true, 0},
+		{"waits at least a second", windowStart.Add(224*time.Second + 500*time.Millisecond), 5, 8, false, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRateLimits{current: tt.current, previous: tt.previous}
+			l := &RateLimiter{repo: repo, prefix: "login:user:", normalize: NormalizeUsername, limit: 10, window: 10 * time.Minute}
+
+			allowed, retryAfter, err := l.allowAt(context.Background(), " Ada ", tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if allowed != tt.wantAllowed || retryAfter != tt.wantRetryAfter {
+				t.Errorf("allowAt() = %v, %s; want %v, %s", allowed, retryAfter, tt.wantAllowed, tt.wantRetryAfter)
+			}
+			if repo.key != "login:user:ada" || !repo.windowStart.Equal(windowStart) || !repo.previousStart.Equal(windowStart.Add(-10*time.Minute)) {
+				t.Errorf("Hit(%q, %s, %s), want the normalized key and the current and previous windows", repo.key, repo.windowStart, repo.previousStart)
+			}
+		})
+	}
+
+	failing := &RateLimiter{repo: &fakeRateLimits{err: errors.New("connection refused")}, limit: 10, window: time.Minute}
+	if allowed, _, err := failing.Allow(context.Background(), "ada"); err == nil || allowed {
+		t.Errorf("Allow() = %v, %v; want an error", allowed, err)
+	}
+}
+
+func TestLoginPolicyLockoutDuration(t *testing.T) {
+	policy := LoginPolicy{LockoutThreshold: 5, LockoutBase: time.Minute, LockoutMax: 10 * time.Minute}
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{4, 0},
+		{5, time.Minute},
+		{6, 2 * time.Minute},
+		{8, 8 * time.Minute},
+		{9, 10 * time.Minute},
+		{1000, 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := policy.lockoutDuration(tt.failures); got != tt.want {
+			t.Errorf("lockoutDuration(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"joblog/internal/core/domain"
+	"joblog/pkg/ical"
+
+	"github.com/google/uuid"
+)
+
+type OfferService struct {
+	repo       domain.OfferRepository
+	appService *ApplicationService
+}
+
+func NewOfferService(repo domain.OfferRepository, appService *ApplicationService) *OfferService {
+	return &OfferService{repo: repo, appService: appService}
+}
+
+// Create records the offer on an application. Each application has at most one offer.
+func (s *OfferService) Create(ctx context.Context, userID, appID string, newOffer domain.NewOffer) (*domain.Offer, error) {
+	app, err := s.appService.GetByID(ctx, userID, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	offer := &domain.Offer{
+		ID:               uuid.NewString(),
+		ApplicationID:    app.ID,
+		UserID:           userID,
+		Currency:         newOffer.Currency,
+		BaseSalary:       newOffer.BaseSalary,
+		PayPeriod:        newOffer.PayPeriod,
+		Bonus:            newOffer.Bonus,
+		SigningBonus:     newOffer.SigningBonus,
+		Equity:           newOffer.Equity,
+		Benefits:         newOffer.Benefits,
+		StartDate:        newOffer.StartDate,
+		DecisionDeadline: newOffer.DecisionDeadline,
+		Decision:         domain.DecisionPending,
+		Negotiations:     []domain.NegotiationEntry{},
+		Notes:            newOffer.Notes,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if offer.PayPeriod == "" {
+		offer.PayPeriod = domain.PayYearly
+	}
+	normalizeOffer(offer)
+	if err := validateOffer(offer); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, offer); err != nil {
+		return nil, err
+	}
+	return offer, nil
+}
+
+// GetForApplication returns the offer on one of the user's applications.
+func (s *OfferService) GetForApplication(ctx context.Context, userID, appID string) (*domain.Offer, error) {
+	if _, err := s.appService.GetByID(ctx, userID, appID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByApplicationID(ctx, appID)
+}
+
+func (s *OfferService) List(ctx context.Context, userID string) ([]*domain.Offer, error) {
+	return s.repo.ListByUserID(ctx, userID)
+}
+
+func (s *OfferService) Update(ctx context.Context, userID, appID string, updateData domain.OfferUpdate) (*domain.Offer, error) {
+	current, err := s.GetForApplication(ctx, userID, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Work on a copy so a failed validation leaves the stored offer untouched
+	offer := *current
+	if updateData.Currency != nil {
+		offer.Currency = *updateData.Currency
+	}
+	if updateData.BaseSalary != nil {
+		offer.BaseSalary = *updateData.BaseSalary
+	}
+	if updateData.PayPeriod != nil {
+		offer.PayPeriod = *updateData.PayPeriod
+	}
+	if updateData.Bonus != nil {
+		offer.Bonus = *updateData.Bonus
+	}
+	if updateData.SigningBonus != nil {
+		offer.SigningBonus = *updateData.SigningBonus
+	}
+	if updateData.RemoveEquity {
+		offer.Equity = nil
+	} else if updateData.Equity != nil {
+		offer.Equity = updateData.Equity
+	}
+	if updateData.Benefits != nil {
+		offer.Benefits = *updateData.Benefits
+	}
+	if updateData.StartDate != nil {
+		offer.StartDate = *updateData.StartDate
+	}
+	if updateData.DecisionDeadline != nil {
+		offer.DecisionDeadline = updateData.DecisionDeadline
+	}
+	if updateData.Decision != nil {
+		offer.Decision = *updateData.Decision
+	}
+	if updateData.Notes != nil {
+		offer.Notes = *updateData.Notes
+	}
+	offer.UpdatedAt = time.Now()
+
+	normalizeOffer(&offer)
+	if err := validateOffer(&offer); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, &offer); err != nil {
+		return nil, err
+	}
+	return &offer, nil
+}
+
+// AddNegotiation appends a round to the offer's negotiation history.
+func (s *OfferService) AddNegotiation(ctx context.Context, userID, appID string, entry domain.NewNegotiationEntry) (*domain.Offer, error) {
+	current, err := s.GetForApplication(ctx, userID, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch entry.Party {
+	case domain.PartyCandidate, domain.PartyEmployer:
+	default:
+		return nil, fmt.Errorf("%w: unknown negotiation party %q", domain.ErrInvalidInput, entry.Party)
+	}
+	if entry.BaseSalary != nil && *entry.BaseSalary < 0 {
+		return nil, fmt.Errorf("%w: salary cannot be negative", domain.ErrInvalidInput)
+	}
+
+	offer := *current
+	offer.Negotiations = append(append([]domain.NegotiationEntry{}, current.Negotiations...), domain.NegotiationEntry{
+		Date:       time.Now(),
+		Party:      entry.Party,
+		BaseSalary: entry.BaseSalary,
+		Note:       strings.TrimSpace(entry.Note),
+	})
+	offer.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, &offer); err != nil {
+		return nil, err
+	}
+	return &offer, nil
+}
+
+func (s *OfferService) Delete(ctx context.Context, userID, appID string) error {
+	offer, err := s.GetForApplication(ctx, userID, appID)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, offer.ID)
+}
+
+// maxCompared caps how many offers can be compared at once.
+const maxCompared = 10
+
+// Compare normalizes the given offers to yearly amounts, in the order they were asked for.
+func (s *OfferService) Compare(ctx context.Context, userID string, offerIDs []string) ([]domain.OfferComparison, error) {
+	if len(offerIDs) == 0 {
+		return nil, fmt.Errorf("%w: at least one offer ID is required", domain.ErrInvalidInput)
+	}
+	if len(offerIDs) > maxCompared {
+		return nil, fmt.Errorf("%w: at most %d offers can be compared", domain.ErrInvalidInput, maxCompared)
+	}
+
+	comparisons := make([]domain.OfferComparison, 0, len(offerIDs))
+	for _, id := range offerIDs {
+		offer, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if offer.UserID != userID {
+			return nil, fmt.Errorf("offer %w", domain.ErrNotFound)
+		}
+		app, err := s.appService.GetByID(ctx, userID, offer.ApplicationID)
+		if err != nil {
+			return nil, err
+		}
+		comparisons = append(comparisons, compareOffer(offer, app))
+	}
+	return comparisons, nil
+}
+
+// hoursPerYear assumes a 40-hour week, 52 weeks a year.
+const hoursPerYear = 40 * 52
+
+func compareOffer(offer *domain.Offer, app *domain.Application) domain.OfferComparison {
+	annualBase := offer.BaseSalary
+	switch offer.PayPeriod {
+	case domain.PayHourly:
+		annualBase *= hoursPerYear
+	case domain.PayMonthly:
+		annualBase *= 12
+	}
+
+	// Nothing has vested by the end of the first year if the cliff is longer than a year
+	var annualEquity, firstYearEquity int
+	if offer.Equity != nil {
+		annualEquity = offer.Equity.Value / offer.Equity.Vesting.Years
+		if offer.Equity.Vesting.CliffMonths <= 12 {
+			firstYearEquity = annualEquity
+		}
+	}
+
+	annualTotal := annualBase + offer.Bonus + annualEquity
+	return domain.OfferComparison{
+		OfferID:          offer.ID,
+		ApplicationID:    offer.ApplicationID,
+		Company:          app.Company,
+		Role:             app.Role,
+		Currency:         offer.Currency,
+		AnnualBase:       annualBase,
+		AnnualBonus:      offer.Bonus,
+		AnnualEquity:     annualEquity,
+		AnnualTotal:      annualTotal,
+		FirstYearTotal:   annualTotal - annualEquity + firstYearEquity + offer.SigningBonus,
+		Benefits:         offer.Benefits,
+		DecisionDeadline: offer.DecisionDeadline,
+		Decision:         offer.Decision,
+	}
+}
+
+// Deadlines returns the pending offers whose decision is due within the given window.
+func (s *OfferService) Deadlines(ctx context.Context, userID string, within time.Duration) ([]domain.OfferDeadline, error) {
+	now := time.Now()
+	offers, err := s.repo.ListDeadlines(ctx, userID, now, now.Add(within))
+	if err != nil {
+		return nil, err
+	}
+
+	deadlines := make([]domain.OfferDeadline, 0, len(offers))
+	for _, offer := range offers {
+		app, err := s.appService.GetByID(ctx, userID, offer.ApplicationID)
+		if err != nil {
+			return nil, err
+		}
+		deadlines = append(deadlines, domain.OfferDeadline{Offer: *offer, Company: app.Company, Role: app.Role})
+	}
+	return deadlines, nil
+}
+
+// CalendarEvents implements CalendarEventSource with the decision deadlines of pending offers.
+func (s *OfferService) CalendarEvents(ctx context.Context, userID string) ([]ical.Event, error) {
+	offers, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().Add(-calendarLookback)
+	events := []ical.Event{}
+	for _, offer := range offers {
+		if offer.Decision != domain.DecisionPending || offer.DecisionDeadline == nil || offer.DecisionDeadline.Before(since) {
+			continue
+		}
+		app, err := s.appService.GetByID(ctx, userID, offer.ApplicationID)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, ical.Event{
+			UID:          "offer-" + offer.ID + "@joblog",
+			Summary:      fmt.Sprintf("Offer deadline: %s", app.Company),
+			Description:  fmt.Sprintf("Decide on the %s offer from %s", app.Role, app.Company),
+			Start:        *offer.DecisionDeadline,
+			End:          offer.DecisionDeadline.Add(15 * time.Minute),
+			LastModified: offer.UpdatedAt,
+		})
+	}
+	return events, nil
+}
+
+func normalizeOffer(offer *domain.Offer) {
+	offer.Currency = strings.ToUpper(strings.TrimSpace(offer.Currency))
+	offer.StartDate = strings.TrimSpace(offer.StartDate)
+	benefits := []string{}
+	for _, benefit := range offer.Benefits {
+		if benefit = strings.TrimSpace(benefit); benefit != "" {
+			benefits = append(benefits, benefit)
+		}
+	}
+	offer.Benefits = benefits
+}
+
+func validateOffer(offer *domain.Offer) error {
+	if !isCurrencyCode(offer.Currency) {
+		return fmt.Errorf("%w: %q is not an ISO 4217 currency code", domain.ErrInvalidInput, offer.Currency)
+	}
+	switch offer.PayPeriod {
+	case domain.PayHourly, domain.PayMonthly, domain.PayYearly:
+	default:
+		return fmt.Errorf("%w: unknown pay period %q", domain.ErrInvalidInput, offer.PayPeriod)
+	}
+	switch offer.Decision {
+	case domain.DecisionPending, domain.DecisionAccepted, domain.DecisionDeclined:
+	default:
+		return fmt.Errorf("%w: unknown decision %q", domain.ErrInvalidInput, offer.Decision)
+	}
+
+	if offer.BaseSalary <= 0 {
+		return fmt.Errorf("%w: base salary must be positive", domain.ErrInvalidInput)
+	}
+	if offer.Bonus < 0 || offer.SigningBonus < 0 {
+		return fmt.Errorf("%w: bonuses cannot be negative", domain.ErrInvalidInput)
+	}
+	if offer.StartDate != "" {
+		if _, err := time.Parse("2006-01-02", offer.StartDate); err != nil {
+			return fmt.Errorf("%w: start date %q must be in YYYY-MM-DD format", domain.ErrInvalidInput, offer.StartDate)
+		}
+	}
+
+	if equity := offer.Equity; equity != nil {
+		switch equity.Type {
+		case domain.EquityRSU, domain.EquityOptions, domain.EquityOther:
+		default:
+			return fmt.Errorf("%w: unknown equity type %q", domain.ErrInvalidInput, equity.Type)
+		}
+		if equity.Value < 0 || equity.Shares < 0 {
+			return fmt.Errorf("%w: equity cannot be negative", domain.ErrInvalidInput)
+		}
+		if equity.Vesting.Years < 1 || equity.Vesting.Years > 10 {
+			return fmt.Errorf("%w: vesting must be between 1 and 10 years", domain.ErrInvalidInput)
+		}
+		if equity.Vesting.CliffMonths < 0 || equity.Vesting.CliffMonths > equity.Vesting.Years*12 {
+			return fmt.Errorf("%w: the vesting cliff must fall within the vesting period", domain.ErrInvalidInput)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"joblog/internal/core/domain"
+	"joblog/pkg/ical"
+	"joblog/pkg/notify"
+
+	"github.com/google/uuid"
+)
+
+type ReminderService struct {
+	reminders  domain.ReminderRepository
+	rules      domain.FollowUpRuleRepository
+	users      domain.UserRepository
+	appService *ApplicationService
+	workflows  *WorkflowService
+	notifier   notify.Notifier
+}
+
+func NewReminderService(
+	reminders domain.ReminderRepository,
+	rules domain.FollowUpRuleRepository,
+	users domain.UserRepository,
+	appService *ApplicationService,
+	workflows *WorkflowService,
+	notifier notify.Notifier,
+) *ReminderService {
+	return &ReminderService{
+		reminders:  reminders,
+		rules:      rules,
+		users:      users,
+		appService: appService,
+		workflows:  workflows,
+		notifier:   notifier,
+	}
+}
+
+// |--- Reminders ---
+
+func (s *ReminderService) Create(ctx context.Context, userID, appID string, newReminder domain.NewReminder) (*domain.Reminder, error) {
+	app, err := s.appService.GetByID(ctx, userID, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reminder := &domain.Reminder{
+		ID:            uuid.NewString(),
+		UserID:        userID,
+		ApplicationID: app.ID,
+		DueAt:         newReminder.DueAt,
+		Message:       strings.TrimSpace(newReminder.Message),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := validateReminder(reminder); err != nil {
+		return nil, err
+	}
+	if err := s.reminders.Create(ctx, reminder); err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+func (s *ReminderService) List(ctx context.Context, userID string) ([]*domain.Reminder, error) {
+	return s.reminders.ListByUserID(ctx, userID)
+}
+
+func (s *ReminderService) ListByApplication(ctx context.Context, userID, appID string) ([]*domain.Reminder, error) {
+	if _, err := s.appService.GetByID(ctx, userID, appID); err != nil {
+		return nil, err
+	}
+	return s.reminders.ListByApplicationID(ctx, appID)
+}
+
+// ListDue returns the user's open reminders whose due time has passed.
+func (s *ReminderService) ListDue(ctx context.Context, userID string) ([]*domain.Reminder, error) {
+	return s.reminders.ListDue(ctx, userID, time.Now())
+}
+
+func (s *ReminderService) GetByID(ctx context.Context, userID, reminderID string) (*domain.Reminder, error) {
+	reminder, err := s.reminders.GetByID(ctx, reminderID)
+	if err != nil {
+		return nil, err
+	}
+	if reminder.UserID != userID {
+		return nil, fmt.Errorf("reminder %w", domain.ErrNotFound)
+	}
+	return reminder, nil
+}
+
+func (s *ReminderService) Update(ctx context.Context, userID, reminderID string, updateData domain.ReminderUpdate) (*domain.Reminder, error) {
+	current, err := s.GetByID(ctx, userID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Work on a copy so a failed validation leaves the stored reminder untouched
+	reminder := *current
+	if updateData.DueAt != nil && !updateData.DueAt.Equal(reminder.DueAt) {
+		reminder.DueAt = *updateData.DueAt
+		// A rescheduled reminder should be delivered again when it comes due, with a fresh set of attempts
+		reminder.NotifiedAt = nil
+		reminder.DeliveryAttempts = 0
+		reminder.NextAttemptAt = nil
+	}
+	if updateData.Message != nil {
+		reminder.Message = strings.TrimSpace(*updateData.Message)
+	}
+	if updateData.Done != nil {
+		reminder.Done = *updateData.Done
+	}
+	reminder.UpdatedAt = time.Now()
+
+	if err := validateReminder(&reminder); err != nil {
+		return nil, err
+	}
+	if err := s.reminders.Update(ctx, &reminder); err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+func (s *ReminderService) Delete(ctx context.Context, userID, reminderID string) error {
+	if _, err := s.GetByID(ctx, userID, reminderID); err != nil {
+		return err
+	}
+	return s.reminders.Delete(ctx, reminderID)
+}
+
+func validateReminder(reminder *domain.Reminder) error {
+	if reminder.Message == "" {
+		return fmt.Errorf("%w: message is required", domain.ErrInvalidInput)
+	}
+	if reminder.DueAt.IsZero() {
+		return fmt.Errorf("%w: due time is required", domain.ErrInvalidInput)
+	}
+	return nil
+}
+
+// |--- Follow-up rules ---
+
+func (s *ReminderService) ListRules(ctx context.Context, userID string) ([]*domain.FollowUpRule, error) {
+	return s.rules.ListByUserID(ctx, userID)
+}
+
+func (s *ReminderService) CreateRule(ctx context.Context, userID string, newRule domain.NewFollowUpRule) (*domain.FollowUpRule, error) {
+	rule := &domain.FollowUpRule{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Status:    newRule.Status,
+		AfterDays: newRule.AfterDays,
+		Message:   strings.TrimSpace(newRule.Message),
+		Enabled:   true,
+	}
+	if rule.Status == "" {
+		rule.Status = domain.StatusApplied
+	}
+	if newRule.Enabled != nil {
+		rule.Enabled = *newRule.Enabled
+	}
+	if err := s.validateRule(ctx, userID, rule); err != nil {
+		return nil, err
+	}
+	if err := s.rules.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *ReminderService) UpdateRule(ctx context.Context, userID, ruleID string, updateData domain.FollowUpRuleUpdate) (*domain.FollowUpRule, error) {
+	current, err := s.rules.GetByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if current.UserID != userID {
+		return nil, fmt.Errorf("follow-up rule %w", domain.ErrNotFound)
+	}
+
+	rule := *current
+	if updateData.Status != nil {
+		rule.Status = *updateData.Status
+	}
+	if updateData.AfterDays != nil {
+		rule.AfterDays = *updateData.AfterDays
+	}
+	if updateData.Message != nil {
+		rule.Message = strings.TrimSpace(*updateData.Message)
+	}
+	if updateData.Enabled != nil {
+		rule.Enabled = *updateData.Enabled
+	}
+
+	if err := s.validateRule(ctx, userID, &rule); err != nil {
+		return nil, err
+	}
+	if err := s.rules.Update(ctx, &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (s *ReminderService) DeleteRule(ctx context.Context, userID, ruleID string) error {
+	rule, err := s.rules.GetByID(ctx, ruleID)
+	if err != nil {
+		return err
+	}
+	if rule.UserID != userID {
+		return fmt.Errorf("follow-up rule %w", domain.ErrNotFound)
+	}
+	return s.rules.Delete(ctx, ruleID)
+}
+
+const maxFollowUpDays = 365
+
+func (s *ReminderService) validateRule(ctx context.Context, userID string, rule *domain.FollowUpRule) error {
+	if rule.AfterDays < 1 || rule.AfterDays > maxFollowUpDays {
+		return fmt.Errorf("%w: afterDays must be between 1 and %d", domain.ErrInvalidInput, maxFollowUpDays)
+	}
+	workflow, err := s.workflows.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !workflow.HasStatus(rule.Status) {
+		return fmt.Errorf("%w: status %q is not part of your workflow", domain.ErrInvalidInput, rule.Status)
+	}
+	return nil
+}
+
+// |--- Scheduling ---
+
+// EvaluateRules creates a reminder for every application that has sat in a rule's
+// status for at least the rule's number of days. Each rule fires once per
+// application per last-update date, so re-running it is harmless. A failing rule
+// doesn't hold up the others; all failures are returned together.
+func (s *ReminderService) EvaluateRules(ctx context.Context, now time.Time) (int, error) {
+	rules, err := s.rules.ListEnabled(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var errs []error
+	for _, rule := range rules {
+		n, err := s.evaluateRule(ctx, rule, now)
+		created += n
+		if err != nil {
+			err = fmt.Errorf("rule %s: %w", rule.ID, err)
+			log.Println("[ReminderService.EvaluateRules] Error:", err)
+			errs = append(errs, err)
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+func (s *ReminderService) evaluateRule(ctx context.Context, rule *domain.FollowUpRule, now time.Time) (int, error) {
+	// updatedAt is a date, so an application last touched on day D is due on D+AfterDays
+	today := now.UTC().Truncate(24 * time.Hour)
+	filter := domain.ApplicationFilter{
+		Statuses:      []domain.ApplicationStatus{rule.Status},
+		UpdatedBefore: today.AddDate(0, 0, 1-rule.AfterDays).Format("2006-01-02"),
+		Sort:          domain.SortByUpdatedAt,
+		Limit:         maxListLimit,
+	}
+
+	created := 0
+	for {
+		page, err := s.appService.List(ctx, rule.UserID, filter)
+		if err != nil {
+			return created, err
+		}
+
+		for _, app := range page.Applications {
+			exists, err := s.reminders.ExistsForRule(ctx, app.ID, rule.ID, app.UpdatedAt)
+			if err != nil {
+				return created, err
+			}
+			if exists {
+				continue
+			}
+
+			lastUpdate, err := time.Parse("2006-01-02", app.UpdatedAt)
+			if err != nil {
+				return created, fmt.Errorf("application %s has an invalid updatedAt: %w", app.ID, err)
+			}
+
+			message := rule.Message
+			if message == "" {
+				message = fmt.Sprintf("Follow up with %s about the %s role (still %s after %d days)", app.Company, app.Role, app.Status, rule.AfterDays)
+			}
+
+			reminder := &domain.Reminder{
+				ID:            uuid.NewString(),
+				UserID:        rule.UserID,
+				ApplicationID: app.ID,
+				DueAt:         lastUpdate.AddDate(0, 0, rule.AfterDays),
+				Message:       message,
+				RuleID:        rule.ID,
+				RuleAnchor:    app.UpdatedAt,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			}
+			if err := s.reminders.Create(ctx, reminder); err != nil {
+				return created, err
+			}
+			created++
+		}
+
+		if page.NextCursor == nil {
+			return created, nil
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
+
+const (
+	deliveryBatchSize = 100
+	// A reminder that fails this many times in a row is no longer retried
+	maxDeliveryAttempts = 8
+	// Retries wait deliveryRetryBase, doubling after every failure up to deliveryRetryMax
+	deliveryRetryBase = 5 * time.Minute
+	deliveryRetryMax  = 6 * time.Hour
+)
+
+// DeliverDue sends every due reminder that hasn't been delivered yet through the notifier.
+// Reminders that fail to send are released and retried later with exponential backoff,
+// until maxDeliveryAttempts is reached.
+func (s *ReminderService) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	delivered := 0
+	for {
+		batch, err := s.reminders.ClaimUndelivered(ctx, now, deliveryBatchSize, maxDeliveryAttempts)
+		if err != nil {
+			return delivered, err
+		}
+
+		failed := 0
+		for _, reminder := range batch {
+			if err := s.deliver(ctx, reminder); err != nil {
+				log.Println("[ReminderService.DeliverDue] Error: ", err)
+				failed++
+				if reminder.DeliveryAttempts+1 >= maxDeliveryAttempts {
+					log.Printf("[ReminderService.DeliverDue] Giving up on reminder %s after %d attempts", reminder.ID, maxDeliveryAttempts)
+				}
+				if err := s.reminders.ReleaseClaim(ctx, reminder.ID, now.Add(deliveryRetryDelay(reminder.DeliveryAttempts))); err != nil {
+					return delivered, err
+				}
+				continue
+			}
+			delivered++
+		}
+
+		// Stop on a short batch, or when nothing went out so released reminders aren't retried in a tight loop
+		if len(batch) < deliveryBatchSize || failed == len(batch) {
+			return delivered, nil
+		}
+	}
+}
+
+// deliveryRetryDelay is how long to wait after a reminder's attempts-th earlier failure
+// (counting from zero) before sending it again.
+func deliveryRetryDelay(attempts int) time.Duration {
+	delay := deliveryRetryBase
+	for i := 0; i < attempts && delay < deliveryRetryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, deliveryRetryMax)
+}
+
+func (s *ReminderService) deliver(ctx context.Context, reminder *domain.Reminder) error {
+	user, err := s.users.GetByID(ctx, reminder.UserID)
+	if err != nil {
+		return fmt.Errorf("reminder %s: %w", reminder.ID, err)
+	}
+	app, err := s.appService.GetByID(ctx, reminder.UserID, reminder.ApplicationID)
+	if err != nil {
+		return fmt.Errorf("reminder %s: %w", reminder.ID, err)
+	}
+
+	return s.notifier.Notify(ctx, notify.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("Reminder: %s - %s", app.Company, app.Role),
+		Body:    fmt.Sprintf("%s\n\nStatus: %s\nLast updated: %s", reminder.Message, app.Status, app.UpdatedAt),
+		Meta: map[string]string{
+			"reminderId":    reminder.ID,
+			"applicationId": app.ID,
+		},
+	})
+}
+
+// CalendarEvents implements CalendarEventSource. Open reminders show up as short events at their due time.
+func (s *ReminderService) CalendarEvents(ctx context.Context, userID string) ([]ical.Event, error) {
+	reminders, err := s.reminders.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().Add(-calendarLookback)
+	apps := make(map[string]*domain.Application)
+	events := []ical.Event{}
+	for _, reminder := range reminders {
+		if reminder.Done || reminder.DueAt.Before(since) {
+			continue
+		}
+
+		app, ok := apps[reminder.ApplicationID]
+		if !ok {
+			if app, err = s.appService.GetByID(ctx, userID, reminder.ApplicationID); err != nil {
+				return nil, err
+			}
+			apps[reminder.ApplicationID] = app
+		}
+
+		events = append(events, ical.Event{
+			UID:          "reminder-" + reminder.ID + "@joblog",
+			Summary:      fmt.Sprintf("Follow up: %s", app.Company),
+			Description:  fmt.Sprintf("%s\n\n%s at %s (%s)", reminder.Message, app.Role, app.Company, app.Status),
+			Start:        reminder.DueAt,
+			End:          reminder.DueAt.Add(15 * time.Minute),
+			LastModified: reminder.UpdatedAt,
+		})
+	}
+	return events, nil
+}
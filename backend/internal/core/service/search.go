@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"joblog/internal/core/domain"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+	maxSearchLength    = 200
+)
+
+type SearchService struct {
+	repo domain.SearchRepository
+}
+
+func NewSearchService(repo domain.SearchRepository) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// Search finds the user's applications whose company, role, notes or history contain
+// every word of the query. A limit of 0 means the default.
+func (s *SearchService) Search(ctx context.Context, userID, query string, limit int) ([]*domain.SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: a search query is required", domain.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(query) > maxSearchLength {
+		return nil, fmt.Errorf("%w: search query cannot be longer than %d characters", domain.ErrInvalidInput, maxSearchLength)
+	}
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	if limit < 0 || limit > maxSearchLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidInput, maxSearchLimit)
+	}
+	return s.repo.Search(ctx, userID, query, limit)
+}
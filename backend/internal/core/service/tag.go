@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"joblog/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultTagColor  = "#6b7280"
+	maxTagNameLength = 50
+)
+
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+type TagService struct {
+	repo       domain.TagRepository
+	appService *ApplicationService
+}
+
+func NewTagService(repo domain.TagRepository, appService *ApplicationService) *TagService {
+	return &TagService{repo: repo, appService: appService}
+}
+
+// List returns the user's tags with how many applications carry each.
+func (s *TagService) List(ctx context.Context, userID string) ([]*domain.TagSummary, error) {
+	return s.repo.ListSummaries(ctx, userID)
+}
+
+func (s *TagService) Create(ctx context.Context, userID string, newTag domain.NewTag) (*domain.Tag, error) {
+	tag := &domain.Tag{
+		ID:     uuid.NewString(),
+		UserID: userID,
+		Name:   strings.TrimSpace(newTag.Name),
+		Color:  strings.ToLower(strings.TrimSpace(newTag.Color)),
+	}
+	if tag.Color == "" {
+		tag.Color = defaultTagColor
+	}
+	if err := validateTag(tag); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (s *TagService) GetByID(ctx context.Context, userID, tagID string) (*domain.Tag, error) {
+	tag, err := s.repo.GetByID(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+	if tag.UserID != userID {
+		return nil, fmt.Errorf("tag %w", domain.ErrNotFound)
+	}
+	return tag, nil
+}
+
+// Update renames or recolors a tag. Every application carrying it sees the change.
+func (s *TagService) Update(ctx context.Context, userID, tagID string, updateData domain.TagUpdate) (*domain.Tag, error) {
+	current, err := s.GetByID(ctx, userID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := *current
+	if updateData.Name != nil {
+		tag.Name = strings.TrimSpace(*updateData.Name)
+	}
+	if updateData.Color != nil {
+		tag.Color = strings.ToLower(strings.TrimSpace(*updateData.Color))
+	}
+	if err := validateTag(&tag); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// Delete removes the tag from every application and then deletes it.
+func (s *TagService) Delete(ctx context.Context, userID, tagID string) error {
+	if _, err := s.GetByID(ctx, userID, tagID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, tagID)
+}
+
+// Merge retags every application carrying source with target, then deletes source.
+func (s *TagService) Merge(ctx context.Context, userID, sourceID, targetID string) (*domain.Tag, error) {
+	if sourceID == targetID {
+		return nil, fmt.Errorf("%w: cannot merge a tag into itself", domain.ErrInvalidInput)
+	}
+	if _, err := s.GetByID(ctx, userID, sourceID); err != nil {
+		return nil, err
+	}
+	target, err := s.GetByID(ctx, userID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Merge(ctx, sourceID, targetID); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// Assign tags one of the user's applications and returns its tags.
+func (s *TagService) Assign(ctx context.Context, userID, appID, tagID string) ([]domain.Tag, error) {
+	if _, err := s.appService.GetByID(ctx, userID, appID); err != nil {
+		return nil, err
+	}
+	if _, err := s.GetByID(ctx, userID, tagID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Assign(ctx, appID, tagID); err != nil {
+		return nil, err
+	}
+
+	app, err := s.appService.GetByID(ctx, userID, appID)
+	if err != nil {
+		return nil, err
+	}
+	return app.Tags, nil
+}
+
+func (s *TagService) Unassign(ctx context.Context, userID, appID, tagID string) error {
+	if _, err := s.appService.GetByID(ctx, userID, appID); err != nil {
+		return err
+	}
+	return s.repo.Unassign(ctx, appID, tagID)
+}
+
+func validateTag(tag *domain.Tag) error {
+	if tag.Name == "" {
+		return fmt.Errorf("%w: tag name is required", domain.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(tag.Name) > maxTagNameLength {
+		return fmt.Errorf("%w: tag name cannot be longer than %d characters", domain.ErrInvalidInput, maxTagNameLength)
+	}
+	// The list filter takes comma-separated tag names
+	if strings.Contains(tag.Name, ",") {
+		return fmt.Errorf("%w: tag name cannot contain a comma", domain.ErrInvalidInput)
+	}
+	if !tagColorPattern.MatchString(tag.Color) {
+		return fmt.Errorf("%w: color must look like #rrggbb", domain.ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+
+	"joblog/internal/core/domain"
+)
+
+type WorkflowService struct {
+	repo domain.WorkflowRepository
+}
+
+func NewWorkflowService(repo domain.WorkflowRepository) *WorkflowService {
+	return &WorkflowService{repo: repo}
+}
+
+// Get returns the user's workflow, or the default one if they have not defined their own.
+func (s *WorkflowService) Get(ctx context.Context, userID string) (*domain.Workflow, error) {
+	workflow, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if workflow == nil {
+		return domain.DefaultWorkflow(), nil
+	}
+	return workflow, nil
+}
+
+func (s *WorkflowService) Update(ctx context.Context, userID string, workflow *domain.Workflow) (*domain.Workflow, error) {
+	if err := workflow.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, userID, workflow); err != nil {
+		return nil, err
+	}
+	return workflow, nil
+}
+
+// Reset drops the user's customizations and returns the default workflow.
+func (s *WorkflowService) Reset(ctx context.Context, userID string) (*domain.Workflow, error) {
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return nil, err
+	}
+	return domain.DefaultWorkflow(), nil
+}
+
+// ValidateInitial checks that a new application may start in the given status.
+func (s *WorkflowService) ValidateInitial(ctx context.Context, userID string, status domain.ApplicationStatus) error {
+	workflow, err := s.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return workflow.ValidateInitial(status)
+}
+
+// ValidateTransition checks a status change against the user's workflow.
+func (s *WorkflowService) ValidateTransition(ctx context.Context, userID string, from, to domain.ApplicationStatus, reason string) error {
+	workflow, err := s.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return workflow.ValidateTransition(from, to, reason)
+}
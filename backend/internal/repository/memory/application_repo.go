@@ -1,45 +1,165 @@
-
 package memory
 
 import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"joblog/internal/core/domain"
 )
 
 type ApplicationRepository struct {
 	apps map[string]*domain.Application
-	mu   sync.RWMutex
+	mu   *sync.RWMutex
 }
 
 func NewApplicationRepository() *ApplicationRepository {
-	return &ApplicationRepository{apps: mockApplications}
+	return &ApplicationRepository{apps: mockApplications, mu: &storeMu}
 }
 
 func (r *ApplicationRepository) Create(ctx context.Context, app *domain.Application) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	app.CompanyID = resolveCompany(app.UserID, app.Company)
 	r.apps[app.ID] = app
+	mockSearchIndex.index(app)
 	return nil
 }
 
-func (r *ApplicationRepository) GetAllByUserID(ctx context.Context, userID string) ([]*domain.Application, error) {
+func (r *ApplicationRepository) List(ctx context.Context, userID string, filter domain.ApplicationFilter) (*domain.ApplicationPage, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
+
+	statuses := make(map[domain.ApplicationStatus]bool, len(filter.Statuses))
+	for _, status := range filter.Statuses {
+		statuses[status] = true
+	}
+	company := strings.ToLower(filter.Company)
+	role := strings.ToLower(filter.Role)
+	tagIDs := make(map[string]bool)
+	for _, name := range filter.Tags {
+		for _, tag := range mockTags {
+			if tag.UserID == userID && strings.EqualFold(tag.Name, name) {
+				tagIDs[tag.ID] = true
+			}
+		}
+	}
+
 	var userApps []*domain.Application
 	for _, app := range r.apps {
-		if app.UserID == userID && app.Status != domain.StatusArchived {
-			userApps = append(userApps, app)
+		if app.UserID != userID || (!filter.IncludeArchived && app.Status == domain.StatusArchived) {
+			continue
+		}
+		if len(statuses) > 0 && !statuses[app.Status] {
+			continue
+		}
+		if company != "" && !strings.Contains(strings.ToLower(app.Company), company) {
+			continue
+		}
+		if role != "" && !strings.Contains(strings.ToLower(app.Role), role) {
+			continue
+		}
+		if filter.DateFrom != "" && app.Date < filter.DateFrom {
+			continue
+		}
+		if filter.DateTo != "" && app.Date > filter.DateTo {
+			continue
+		}
+		if filter.UpdatedBefore != "" && app.UpdatedAt >= filter.UpdatedBefore {
+			continue
+		}
+		if len(filter.Tags) > 0 && !hasAnyTag(app.ID, tagIDs) {
+			continue
+		}
+		if !matchesCustomFields(app.CustomFields, filter.CustomFields) {
+			continue
+		}
+		if filter.Cursor != nil && !after(sortKey(app, filter.Sort), app.ID, filter.Cursor, filter.Descending) {
+			continue
 		}
+		userApps = append(userApps, app)
 	}
-	// Sort by date descending
+
 	sort.Slice(userApps, func(i, j int) bool {
-		return userApps[i].Date > userApps[j].Date
+		ki, kj := sortKey(userApps[i], filter.Sort), sortKey(userApps[j], filter.Sort)
+		if ki == kj {
+			ki, kj = userApps[i].ID, userApps[j].ID
+		}
+		if filter.Descending {
+			return ki > kj
+		}
+		return ki < kj
 	})
-	return userApps, nil
+
+	page := &domain.ApplicationPage{Applications: []*domain.Application{}}
+	if len(userApps) > filter.Limit {
+		last := userApps[filter.Limit-1]
+		page.NextCursor = &domain.ApplicationCursor{
+			Sort:       filter.Sort,
+			Descending: filter.Descending,
+			Key:        sortKey(last, filter.Sort),
+			ID:         last.ID,
+		}
+		userApps = userApps[:filter.Limit]
+	}
+	for _, app := range userApps {
+		page.Applications = append(page.Applications, withTags(app))
+	}
+	return page, nil
+}
+
+// withTags returns a copy of the application with its tags filled in, so the
+// stored application never holds a stale list. storeMu must be held.
+func withTags(app *domain.Application) *domain.Application {
+	tagged := *app
+	tagged.Tags = tagsOf(app.ID)
+	return &tagged
+}
+
+// matchesCustomFields mirrors postgres' jsonb containment for scalar values.
+func matchesCustomFields(values, want domain.CustomFieldValues) bool {
+	for key, value := range want {
+		if got, ok := values[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
+func hasAnyTag(applicationID string, tagIDs map[string]bool) bool {
+	for _, tagID := range mockApplicationTags[applicationID] {
+		if tagIDs[tagID] {
+			return true
+		}
+	}
+	return false
+}
+
+// sortKey mirrors the text sort keys used by the postgres repository.
+func sortKey(app *domain.Application, sort domain.ApplicationSort) string {
+	switch sort {
+	case domain.SortByUpdatedAt:
+		return app.UpdatedAt
+	case domain.SortByCompany:
+		return strings.ToLower(app.Company)
+	default:
+		return app.Date
+	}
+}
+
+// after reports whether (key, id) comes after the cursor position in the given direction.
+func after(key, id string, cursor *domain.ApplicationCursor, descending bool) bool {
+	cursorKey := cursor.Key
+	if key == cursorKey {
+		key, cursorKey = id, cursor.ID
+	}
+	if descending {
+		return key < cursorKey
+	}
+	return key > cursorKey
 }
 
 func (r *ApplicationRepository) GetByID(ctx context.Context, id string) (*domain.Application, error) {
@@ -47,31 +167,231 @@ func (r *ApplicationRepository) GetByID(ctx context.Context, id string) (*domain
 	defer r.mu.RUnlock()
 	app, ok := r.apps[id]
 	if !ok {
-		return nil, fmt.Errorf("application with ID %s not found", id)
+		return nil, fmt.Errorf("application %w", domain.ErrNotFound)
 	}
-	return app, nil
+	return withTags(app), nil
 }
 
 func (r *ApplicationRepository) Update(ctx context.Context, app *domain.Application) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if _, ok := r.apps[app.ID]; !ok {
-		return fmt.Errorf("application with ID %s not found", app.ID)
+		return fmt.Errorf("application %w", domain.ErrNotFound)
 	}
+	app.CompanyID = resolveCompany(app.UserID, app.Company)
 	r.apps[app.ID] = app
+	mockSearchIndex.index(app)
 	return nil
 }
 
-// Delete is a soft delete (archive) which is handled by the service setting the status.
-// The update method persists this change. This method is here to satisfy the interface.
+// ListByCompanyID returns every application to the company, newest first.
+func (r *ApplicationRepository) ListByCompanyID(ctx context.Context, companyID string) ([]*domain.Application, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	apps := []*domain.Application{}
+	for _, app := range r.apps {
+		if app.CompanyID == companyID {
+			apps = append(apps, withTags(app))
+		}
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		if apps[i].Date != apps[j].Date {
+			return apps[i].Date > apps[j].Date
+		}
+		return apps[i].ID > apps[j].ID
+	})
+	return apps, nil
+}
+
+// Delete permanently removes an application together with its notes, history
+// and the records hanging off it, like postgres' ON DELETE CASCADE.
 func (r *ApplicationRepository) Delete(ctx context.Context, id string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if _, ok := r.apps[id]; !ok {
-		return fmt.Errorf("application with ID %s not found", id)
+		return fmt.Errorf("application %w", domain.ErrNotFound)
+	}
+	r.delete(id)
+	return nil
+}
+
+// ApplyBatch checks every application exists before changing anything, so a batch is
+// applied completely or not at all.
+func (r *ApplicationRepository) ApplyBatch(ctx context.Context, batch domain.ApplicationBatch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.applyBatch(batch)
+}
+
+// LockAndApplyBatch holds the store's lock from loading the applications until the batch is applied.
+func (r *ApplicationRepository) LockAndApplyBatch(ctx context.Context, ids []string, plan func([]*domain.Application) (domain.ApplicationBatch, error)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	apps := []*domain.Application{}
+	for _, id := range ids {
+		if app, ok := r.apps[id]; ok {
+			apps = append(apps, withTags(app))
+		}
+	}
+	batch, err := plan(apps)
+	if err != nil {
+		return err
+	}
+	return r.applyBatch(batch)
+}
+
+// applyBatch does the work of ApplyBatch. storeMu must be held.
+func (r *ApplicationRepository) applyBatch(batch domain.ApplicationBatch) error {
+	for _, app := range batch.Updates {
+		if _, ok := r.apps[app.ID]; !ok {
+			return fmt.Errorf("application %w", domain.ErrNotFound)
+		}
+	}
+	for _, id := range batch.Deletes {
+		if _, ok := r.apps[id]; !ok {
+			return fmt.Errorf("application %w", domain.ErrNotFound)
+		}
+	}
+	newTags := make(map[string]bool, len(batch.Tags))
+	for _, tag := range batch.Tags {
+		for _, existing := range mockTags {
+			if existing.UserID == tag.UserID && strings.EqualFold(existing.Name, tag.Name) {
+				return fmt.Errorf("%w: a tag named %q already exists", domain.ErrConflict, tag.Name)
+			}
+		}
+		newTags[tag.ID] = true
+	}
+	for _, field := range batch.CustomFields {
+		for _, existing := range mockCustomFields {
+			if existing.UserID == field.UserID && existing.Key == field.Key {
+				return fmt.Errorf("%w: a custom field with key %q already exists", domain.ErrConflict, field.Key)
+			}
+		}
+	}
+	for _, assignment := range batch.TagAssignments {
+		if _, ok := mockTags[assignment.TagID]; !ok && !newTags[assignment.TagID] {
+			return fmt.Errorf("tag %w", domain.ErrNotFound)
+		}
+	}
+
+	for _, tag := range batch.Tags {
+		mockTags[tag.ID] = tag
+	}
+	for _, field := range batch.CustomFields {
+		mockCustomFields[field.ID] = field
+	}
+	for _, app := range append(batch.Creates, batch.Updates...) {
+		app.CompanyID = resolveCompany(app.UserID, app.Company)
+		r.apps[app.ID] = app
+		mockSearchIndex.index(app)
+	}
+	for _, id := range batch.Deletes {
+		r.delete(id)
+	}
+	for _, assignment := range batch.TagAssignments {
+		assignTag(assignment.ApplicationID, assignment.TagID)
 	}
-	// The service layer handles setting the 'Archived' status.
-	// This method could be a hard delete if needed, e.g., delete(r.apps, id)
-	// But based on the OpenAPI spec, we just update the status.
 	return nil
 }
+
+// delete removes the application and cascades to its records. storeMu must be held.
+func (r *ApplicationRepository) delete(id string) {
+	delete(r.apps, id)
+	for interviewID, interview := range mockInterviews {
+		if interview.ApplicationID == id {
+			delete(mockInterviews, interviewID)
+		}
+	}
+	for reminderID, reminder := range mockReminders {
+		if reminder.ApplicationID == id {
+			delete(mockReminders, reminderID)
+		}
+	}
+	for offerID, offer := range mockOffers {
+		if offer.ApplicationID == id {
+			delete(mockOffers, offerID)
+		}
+	}
+	delete(mockContactLinks, id)
+	delete(mockApplicationTags, id)
+	mockSearchIndex.remove(id)
+}
+
+func (r *ApplicationRepository) FindByCompanyName(ctx context.Context, userID, normalizedName string) ([]*domain.Application, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	companyIDs := make(map[string]bool)
+	for _, company := range mockCompanies {
+		if company.UserID == userID && company.NormalizedName == normalizedName {
+			companyIDs[company.ID] = true
+		}
+	}
+
+	apps := []*domain.Application{}
+	for _, app := range r.apps {
+		if companyIDs[app.CompanyID] {
+			copied := *app
+			apps = append(apps, &copied)
+		}
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		if apps[i].Date != apps[j].Date {
+			return apps[i].Date > apps[j].Date
+		}
+		return apps[i].ID > apps[j].ID
+	})
+	return apps, nil
+}
+
+// GetStatsByUserID computes the same statistics as the postgres repository, in Go.
+func (r *ApplicationRepository) GetStatsByUserID(ctx context.Context, userID string) (*domain.ApplicationStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := &domain.ApplicationStats{
+		StatusBreakdown:     make(map[domain.ApplicationStatus]int),
+		ApplicationsPerWeek: []domain.WeeklyCount{},
+	}
+
+	responded := make(map[domain.ApplicationStatus]bool, len(domain.RespondedStatuses))
+	for _, status := range domain.RespondedStatuses {
+		responded[status] = true
+	}
+
+	respondedCount := 0
+	weekly := make(map[string]int)
+	for _, app := range r.apps {
+		if app.UserID != userID {
+			continue
+		}
+		stats.Total++
+		stats.StatusBreakdown[app.Status]++
+		if app.Status != domain.StatusArchived {
+			stats.Active++
+		}
+		if responded[app.Status] {
+			respondedCount++
+		}
+
+		date, err := time.Parse("2006-01-02", app.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q on application %s: %w", app.Date, app.ID, err)
+		}
+		// Weeks start on Monday, matching postgres' date_trunc('week', ...)
+		weekStart := date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+		weekly[weekStart.Format("2006-01-02")]++
+	}
+
+	if stats.Active > 0 {
+		stats.ResponseRate = float64(respondedCount) / float64(stats.Active)
+	}
+
+	for weekStart, count := range weekly {
+		stats.ApplicationsPerWeek = append(stats.ApplicationsPerWeek, domain.WeeklyCount{WeekStart: weekStart, Count: count})
+	}
+	sort.Slice(stats.ApplicationsPerWeek, func(i, j int) bool {
+		return stats.ApplicationsPerWeek[i].WeekStart < stats.ApplicationsPerWeek[j].WeekStart
+	})
+
+	return stats, nil
+}
@@ -1,4 +1,3 @@
-
 package memory
 
 import (
@@ -12,11 +11,11 @@ import (
 
 type BlogRepository struct {
 	posts map[string]*domain.BlogPost
-	mu    sync.RWMutex
+	mu    *sync.RWMutex
 }
 
 func NewBlogRepository() *BlogRepository {
-	return &BlogRepository{posts: mockBlogPosts}
+	return &BlogRepository{posts: mockBlogPosts, mu: &storeMu}
 }
 
 func (r *BlogRepository) Create(ctx context.Context, post *domain.BlogPost) error {
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+type CalendarTokenRepository struct {
+	tokens map[string]string // User ID -> token hash
+	mu     *sync.RWMutex
+}
+
+func NewCalendarTokenRepository() *CalendarTokenRepository {
+	return &CalendarTokenRepository{tokens: mockCalendarTokens, mu: &storeMu}
+}
+
+func (r *CalendarTokenRepository) Save(ctx context.Context, userID, tokenHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[userID] = tokenHash
+	return nil
+}
+
+func (r *CalendarTokenRepository) GetUserIDByTokenHash(ctx context.Context, tokenHash string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for userID, hash := range r.tokens {
+		if hash == tokenHash {
+			return userID, nil
+		}
+	}
+	return "", fmt.Errorf("calendar token not found")
+}
+
+func (r *CalendarTokenRepository) Delete(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tokens, userID)
+	return nil
+}
@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"joblog/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type CompanyRepository struct {
+	companies map[string]*domain.Company
+	mu        *sync.RWMutex
+}
+
+func NewCompanyRepository() *CompanyRepository {
+	return &CompanyRepository{companies: mockCompanies, mu: &storeMu}
+}
+
+// resolveCompany returns the ID of the user's company matching name, creating
+// the company on first use. Callers must hold storeMu for writing.
+func resolveCompany(userID, name string) string {
+	name = strings.TrimSpace(name)
+	normalized := domain.NormalizeCompanyName(name)
+	for _, company := range mockCompanies {
+		if company.UserID == userID && company.NormalizedName == normalized {
+			return company.ID
+		}
+	}
+
+	now := time.Now()
+	company := &domain.Company{
+		ID:             uuid.NewString(),
+		UserID:         userID,
+		Name:           name,
+		NormalizedName: normalized,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	mockCompanies[company.ID] = company
+	return company.ID
+}
+
+func (r *CompanyRepository) GetByID(ctx context.Context, id string) (*domain.Company, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	company, ok := r.companies[id]
+	if !ok {
+		return nil, fmt.Errorf("company %w", domain.ErrNotFound)
+	}
+	copied := *company
+	return &copied, nil
+}
+
+// ListSummaries computes the same rollups as the postgres repository, in Go.
+func (r *CompanyRepository) ListSummaries(ctx context.Context, userID string) ([]*domain.CompanySummary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	byID := make(map[string]*domain.CompanySummary)
+	summaries := []*domain.CompanySummary{}
+	for _, company := range r.companies {
+		if company.UserID != userID {
+			continue
+		}
+		summary := &domain.CompanySummary{Company: *company, StatusBreakdown: make(map[domain.ApplicationStatus]int)}
+		byID[company.ID] = summary
+		summaries = append(summaries, summary)
+	}
+
+	for _, app := range mockApplications {
+		summary, ok := byID[app.CompanyID]
+		if !ok {
+			continue
+		}
+		summary.ApplicationCount++
+		summary.StatusBreakdown[app.Status]++
+		if app.Date > summary.LastAppliedOn {
+			summary.LastAppliedOn = app.Date
+		}
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := strings.ToLower(summaries[i].Name), strings.ToLower(summaries[j].Name)
+		if a != b {
+			return a < b
+		}
+		return summaries[i].ID < summaries[j].ID
+	})
+	return summaries, nil
+}
+
+func (r *CompanyRepository) Update(ctx context.Context, company *domain.Company) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.companies[company.ID]; !ok {
+		return fmt.Errorf("company %w", domain.ErrNotFound)
+	}
+	for _, other := range r.companies {
+		if other.ID != company.ID && other.UserID == company.UserID && other.NormalizedName == company.NormalizedName {
+			return fmt.Errorf("%w: another company is already named %q", domain.ErrConflict, company.Name)
+		}
+	}
+	r.companies[company.ID] = company
+	return nil
+}
+
+func (r *CompanyRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.companies[id]; !ok {
+		return fmt.Errorf("company %w", domain.ErrNotFound)
+	}
+	for _, app := range mockApplications {
+		if app.CompanyID == id {
+			return fmt.Errorf("%w: the company still has applications", domain.ErrConflict)
+		}
+	}
+	delete(r.companies, id)
+	return nil
+}
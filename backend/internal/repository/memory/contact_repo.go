@@ -0,0 +1,151 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"joblog/internal/core/domain"
+)
+
+// contactLink is one row of the application <-> contact relation.
+type contactLink struct {
+	ContactID string
+	Role      domain.ContactRole
+}
+
+type ContactRepository struct {
+	contacts map[string]*domain.Contact
+	links    map[string][]contactLink // Application ID -> links, in the order they were added
+	mu       *sync.RWMutex
+}
+
+func NewContactRepository() *ContactRepository {
+	return &ContactRepository{contacts: mockContacts, links: mockContactLinks, mu: &storeMu}
+}
+
+func (r *ContactRepository) Create(ctx context.Context, contact *domain.Contact) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.contacts[contact.ID] = contact
+	return nil
+}
+
+func (r *ContactRepository) GetByID(ctx context.Context, id string) (*domain.Contact, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	contact, ok := r.contacts[id]
+	if !ok {
+		return nil, fmt.Errorf("contact %w", domain.ErrNotFound)
+	}
+	return contact, nil
+}
+
+func (r *ContactRepository) List(ctx context.Context, userID, query string) ([]*domain.Contact, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	query = strings.ToLower(query)
+	contacts := []*domain.Contact{}
+	for _, contact := range r.contacts {
+		if contact.UserID != userID {
+			continue
+		}
+		if query != "" && !containsFold(query, contact.Name, contact.Email, contact.Company, contact.Title) {
+			continue
+		}
+		contacts = append(contacts, contact)
+	}
+	sort.Slice(contacts, func(i, j int) bool {
+		a, b := strings.ToLower(contacts[i].Name), strings.ToLower(contacts[j].Name)
+		if a != b {
+			return a < b
+		}
+		return contacts[i].ID < contacts[j].ID
+	})
+	return contacts, nil
+}
+
+// containsFold reports whether any of the fields contains the lower-cased query.
+func containsFold(query string, fields ...string) bool {
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ContactRepository) Update(ctx context.Context, contact *domain.Contact) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.contacts[contact.ID]; !ok {
+		return fmt.Errorf("contact %w", domain.ErrNotFound)
+	}
+	r.contacts[contact.ID] = contact
+	return nil
+}
+
+func (r *ContactRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.contacts[id]; !ok {
+		return fmt.Errorf("contact %w", domain.ErrNotFound)
+	}
+	delete(r.contacts, id)
+	for appID := range r.links {
+		r.removeLink(appID, id)
+	}
+	return nil
+}
+
+func (r *ContactRepository) Link(ctx context.Context, applicationID, contactID string, role domain.ContactRole) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	links := r.links[applicationID]
+	for i := range links {
+		if links[i].ContactID == contactID {
+			links[i].Role = role
+			return nil
+		}
+	}
+	r.links[applicationID] = append(links, contactLink{ContactID: contactID, Role: role})
+	return nil
+}
+
+func (r *ContactRepository) Unlink(ctx context.Context, applicationID, contactID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.removeLink(applicationID, contactID) {
+		return fmt.Errorf("contact is not linked to this application")
+	}
+	return nil
+}
+
+// removeLink drops a single link and reports whether it existed. Callers must hold the lock.
+func (r *ContactRepository) removeLink(applicationID, contactID string) bool {
+	links := r.links[applicationID]
+	for i, link := range links {
+		if link.ContactID == contactID {
+			r.links[applicationID] = append(links[:i:i], links[i+1:]...)
+			if len(r.links[applicationID]) == 0 {
+				delete(r.links, applicationID)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ContactRepository) ListByApplicationID(ctx context.Context, applicationID string) ([]domain.ApplicationContact, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	contacts := []domain.ApplicationContact{}
+	for _, link := range r.links[applicationID] {
+		if contact, ok := r.contacts[link.ContactID]; ok {
+			contacts = append(contacts, domain.ApplicationContact{Contact: *contact, Role: link.Role})
+		}
+	}
+	return contacts, nil
+}
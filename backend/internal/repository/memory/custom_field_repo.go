@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+
+	"joblog/internal/core/domain"
+)
+
+type CustomFieldRepository struct {
+	fields map[string]*domain.CustomField
+	mu     *sync.RWMutex
+}
+
+func NewCustomFieldRepository() *CustomFieldRepository {
+	return &CustomFieldRepository{fields: mockCustomFields, mu: &storeMu}
+}
+
+func (r *CustomFieldRepository) Create(ctx context.Context, field *domain.CustomField) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.fields {
+		if existing.UserID == field.UserID && existing.Key == field.Key {
+			return fmt.Errorf("%w: a custom field with key %q already exists", domain.ErrConflict, field.Key)
+		}
+	}
+	r.fields[field.ID] = field
+	return nil
+}
+
+func (r *CustomFieldRepository) GetByID(ctx context.Context, id string) (*domain.CustomField, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	field, ok := r.fields[id]
+	if !ok {
+		return nil, fmt.Errorf("custom field %w", domain.ErrNotFound)
+	}
+	return field, nil
+}
+
+func (r *CustomFieldRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.CustomField, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fields := []*domain.CustomField{}
+	for _, field := range r.fields {
+		if field.UserID == userID {
+			fields = append(fields, field)
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].CreatedAt.Before(fields[j].CreatedAt)
+	})
+	return fields, nil
+}
+
+func (r *CustomFieldRepository) Update(ctx context.Context, field *domain.CustomField) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.fields[field.ID]; !ok {
+		return fmt.Errorf("custom field %w", domain.ErrNotFound)
+	}
+	r.fields[field.ID] = field
+	return nil
+}
+
+func (r *CustomFieldRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	field, ok := r.fields[id]
+	if !ok {
+		return fmt.Errorf("custom field %w", domain.ErrNotFound)
+	}
+	delete(r.fields, id)
+
+	// Copies of the application handed out earlier share the map, so replace it rather than edit it
+	for _, app := range mockApplications {
+		if _, ok := app.CustomFields[field.Key]; app.UserID == field.UserID && ok {
+			values := maps.Clone(app.CustomFields)
+			delete(values, field.Key)
+			app.CustomFields = values
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"joblog/internal/core/domain"
+)
+
+type InterviewRepository struct {
+	interviews map[string]*domain.Interview
+	mu         *sync.RWMutex
+}
+
+func NewInterviewRepository() *InterviewRepository {
+	return &InterviewRepository{interviews: mockInterviews, mu: &storeMu}
+}
+
+func (r *InterviewRepository) Create(ctx context.Context, interview *domain.Interview) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interviews[interview.ID] = interview
+	return nil
+}
+
+func (r *InterviewRepository) GetByID(ctx context.Context, id string) (*domain.Interview, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	interview, ok := r.interviews[id]
+	if !ok {
+		return nil, fmt.Errorf("interview %w", domain.ErrNotFound)
+	}
+	return interview, nil
+}
+
+func (r *InterviewRepository) ListByApplicationID(ctx context.Context, applicationID string) ([]*domain.Interview, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	interviews := []*domain.Interview{}
+	for _, interview := range r.interviews {
+		if interview.ApplicationID == applicationID {
+			interviews = append(interviews, interview)
+		}
+	}
+	sortInterviews(interviews)
+	return interviews, nil
+}
+
+func (r *InterviewRepository) ListByUserID(ctx context.Context, userID string, since time.Time) ([]*domain.Interview, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	interviews := []*domain.Interview{}
+	for _, interview := range r.interviews {
+		if interview.UserID == userID && !interview.ScheduledAt.Before(since) {
+			interviews = append(interviews, interview)
+		}
+	}
+	sortInterviews(interviews)
+	return interviews, nil
+}
+
+func (r *InterviewRepository) Update(ctx context.Context, interview *domain.Interview) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.interviews[interview.ID]; !ok {
+		return fmt.Errorf("interview %w", domain.ErrNotFound)
+	}
+	r.interviews[interview.ID] = interview
+	return nil
+}
+
+func (r *InterviewRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.interviews[id]; !ok {
+		return fmt.Errorf("interview %w", domain.ErrNotFound)
+	}
+	delete(r.interviews, id)
+	return nil
+}
+
+// sortInterviews orders interviews by scheduled time, earliest first.
+func sortInterviews(interviews []*domain.Interview) {
+	sort.Slice(interviews, func(i, j int) bool {
+		return interviews[i].ScheduledAt.Before(interviews[j].ScheduledAt)
+	})
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"joblog/internal/core/domain"
+)
+
+type LoginAttemptRepository struct {
+	attempts map[string]*domain.LoginAttempt
+	lockouts map[string]*domain.LoginLockout // Keyed by username
+	mu       *sync.RWMutex
+}
+
+func NewLoginAttemptRepository() *LoginAttemptRepository {
+	return &LoginAttemptRepository{attempts: mockLoginAttempts, lockouts: mockLoginLockouts, mu: &storeMu}
+}
+
+func (r *LoginAttemptRepository) Record(ctx context.Context, attempt *domain.LoginAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *attempt
+	r.attempts[attempt.ID] = &stored
+	return nil
+}
+
+func (r *LoginAttemptRepository) GetLockout(ctx context.Context, username string) (*domain.LoginLockout, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	lockout, ok := r.lockouts[username]
+	if !ok {
+		return nil, nil
+	}
+	found := *lockout
+	return &found, nil
+}
+
+func (r *LoginAttemptRepository) AddFailure(ctx context.Context, username string, at, resetBefore time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lockout, ok := r.lockouts[username]
+	if !ok {
+		lockout = &domain.LoginLockout{Username: username}
+		r.lockouts[username] = lockout
+	}
+	if lockout.LastFailureAt.Before(resetBefore) {
+		lockout.Failures = 0
+	}
+	lockout.Failures++
+	lockout.LastFailureAt = at
+	return lockout.Failures, nil
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, username string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if lockout, ok := r.lockouts[username]; ok {
+		lockout.LockedUntil = &until
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) ClearFailures(ctx context.Context, username string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.lockouts, username)
+	return nil
+}
+
+func (r *LoginAttemptRepository) Prune(ctx context.Context, attemptsBefore, failuresBefore time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, attempt := range r.attempts {
+		if attempt.CreatedAt.Before(attemptsBefore) {
+			delete(r.attempts, id)
+		}
+	}
+	now := time.Now()
+	for username, lockout := range r.lockouts {
+		if lockout.LastFailureAt.Before(failuresBefore) && (lockout.LockedUntil == nil || lockout.LockedUntil.Before(now)) {
+			delete(r.lockouts, username)
+		}
+	}
+	return nil
+}
@@ -1,8 +1,8 @@
-
 package memory
 
 import (
 	"log"
+	"sync"
 	"time"
 
 	"joblog/internal/core/domain"
@@ -12,9 +12,31 @@ import (
 )
 
 var (
-	mockUsers        = make(map[string]*domain.User)
-	mockApplications = make(map[string]*domain.Application)
-	mockBlogPosts    = make(map[string]*domain.BlogPost)
+	// storeMu guards all of the maps below. The repositories share one lock so
+	// that operations spanning several of them (like deleting an account) are atomic.
+	storeMu sync.RWMutex
+
+	mockUsers           = make(map[string]*domain.User)
+	mockApplications    = make(map[string]*domain.Application)
+	mockBlogPosts       = make(map[string]*domain.BlogPost)
+	mockWorkflows       = make(map[string]*domain.Workflow) // Keyed by user ID
+	mockInterviews      = make(map[string]*domain.Interview)
+	mockCalendarTokens  = make(map[string]string) // User ID -> token hash
+	mockReminders       = make(map[string]*domain.Reminder)
+	mockFollowUpRules   = make(map[string]*domain.FollowUpRule)
+	mockContacts        = make(map[string]*domain.Contact)
+	mockCompanies       = make(map[string]*domain.Company)
+	mockContactLinks    = make(map[string][]contactLink) // Application ID -> linked contacts
+	mockOffers          = make(map[string]*domain.Offer)
+	mockTags            = make(map[string]*domain.Tag)
+	mockCustomFields    = make(map[string]*domain.CustomField)
+	mockApplicationTags = make(map[string][]string) // Application ID -> tag IDs
+	mockSearchIndex     = newSearchIndex()
+	mockSessions        = make(map[string]*domain.Session)
+	mockUserTokens      = make(map[string]*domain.UserToken)
+	mockLoginAttempts   = make(map[string]*domain.LoginAttempt)
+	mockLoginLockouts   = make(map[string]*domain.LoginLockout) // Keyed by username
+	mockRateLimits      = make(map[rateLimitWindow]int)
 )
 
 func init() {
@@ -48,8 +70,8 @@ func init() {
 			{ID: uuid.NewString(), Content: "Technical screen scheduled for next week.", CreatedAt: time.Now().Add(-15 * 24 * time.Hour)},
 		},
 		History: []domain.HistoryEvent{
-			{Date: time.Now().Add(-30 * 24 * time.Hour), Event: "Application created with status: Applied"},
-			{Date: time.Now().Add(-20 * 24 * time.Hour), Event: "Status updated to: Interviewing"},
+			{Date: time.Now().Add(-30 * 24 * time.Hour), Type: domain.EventCreated, ToStatus: domain.StatusApplied, Event: "Application created with status: Applied"},
+			{Date: time.Now().Add(-20 * 24 * time.Hour), Type: domain.EventStatusChanged, FromStatus: domain.StatusApplied, ToStatus: domain.StatusInterviewing, Event: "Status updated to: Interviewing"},
 		},
 	}
 
@@ -64,11 +86,17 @@ func init() {
 		Status:    domain.StatusRejected,
 		Notes:     []domain.Note{},
 		History: []domain.HistoryEvent{
-			{Date: time.Now().Add(-45 * 24 * time.Hour), Event: "Application created with status: Applied"},
-			{Date: time.Now().Add(-25 * 24 * time.Hour), Event: "Status updated to: Rejected"},
+			{Date: time.Now().Add(-45 * 24 * time.Hour), Type: domain.EventCreated, ToStatus: domain.StatusApplied, Event: "Application created with status: Applied"},
+			{Date: time.Now().Add(-25 * 24 * time.Hour), Type: domain.EventStatusChanged, FromStatus: domain.StatusApplied, ToStatus: domain.StatusRejected, Event: "Status updated to: Rejected"},
 		},
 	}
 
+	for _, app := range mockApplications {
+		app.CompanyID = resolveCompany(app.UserID, app.Company)
+		app.CustomFields = domain.CustomFieldValues{}
+		mockSearchIndex.index(app)
+	}
+
 	// |--- Blog Posts ---
 	post1ID := "post-aaaa-bbbb-cccc"
 	mockBlogPosts[post1ID] = &domain.BlogPost{
@@ -85,6 +113,7 @@ func init() {
 		Comments: []domain.Comment{
 			{
 				ID:        uuid.NewString(),
+				AuthorID:  user1ID,
 				Author:    "John Doe",
 				Avatar:    "https://i.pravatar.cc/150?u=johndoe",
 				Content:   "Great article! Really helpful for getting started.",
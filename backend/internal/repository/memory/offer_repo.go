@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"joblog/internal/core/domain"
+)
+
+type OfferRepository struct {
+	offers map[string]*domain.Offer
+	mu     *sync.RWMutex
+}
+
+func NewOfferRepository() *OfferRepository {
+	return &OfferRepository{offers: mockOffers, mu: &storeMu}
+}
+
+func (r *OfferRepository) Create(ctx context.Context, offer *domain.Offer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.offers {
+		if existing.ApplicationID == offer.ApplicationID {
+			return fmt.Errorf("%w: the application already has an offer", domain.ErrConflict)
+		}
+	}
+	r.offers[offer.ID] = offer
+	return nil
+}
+
+func (r *OfferRepository) GetByID(ctx context.Context, id string) (*domain.Offer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	offer, ok := r.offers[id]
+	if !ok {
+		return nil, fmt.Errorf("offer %w", domain.ErrNotFound)
+	}
+	return offer, nil
+}
+
+func (r *OfferRepository) GetByApplicationID(ctx context.Context, applicationID string) (*domain.Offer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, offer := range r.offers {
+		if offer.ApplicationID == applicationID {
+			return offer, nil
+		}
+	}
+	return nil, fmt.Errorf("offer %w", domain.ErrNotFound)
+}
+
+func (r *OfferRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Offer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	offers := []*domain.Offer{}
+	for _, offer := range r.offers {
+		if offer.UserID == userID {
+			offers = append(offers, offer)
+		}
+	}
+	sort.Slice(offers, func(i, j int) bool {
+		return offers[i].CreatedAt.After(offers[j].CreatedAt)
+	})
+	return offers, nil
+}
+
+func (r *OfferRepository) ListDeadlines(ctx context.Context, userID string, from, to time.Time) ([]*domain.Offer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	offers := []*domain.Offer{}
+	for _, offer := range r.offers {
+		if offer.UserID != userID || offer.Decision != domain.DecisionPending || offer.DecisionDeadline == nil {
+			continue
+		}
+		if offer.DecisionDeadline.Before(from) || offer.DecisionDeadline.After(to) {
+			continue
+		}
+		offers = append(offers, offer)
+	}
+	sort.Slice(offers, func(i, j int) bool {
+		return offers[i].DecisionDeadline.Before(*offers[j].DecisionDeadline)
+	})
+	return offers, nil
+}
+
+func (r *OfferRepository) Update(ctx context.Context, offer *domain.Offer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.offers[offer.ID]; !ok {
+		return fmt.Errorf("offer %w", domain.ErrNotFound)
+	}
+	r.offers[offer.ID] = offer
+	return nil
+}
+
+func (r *OfferRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.offers[id]; !ok {
+		return fmt.Errorf("offer %w", domain.ErrNotFound)
+	}
+	delete(r.offers, id)
+	return nil
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimitWindow identifies one fixed window of a rate limit key.
+type rateLimitWindow struct {
+	key   string
+	start int64 // Unix nanoseconds
+}
+
+// RateLimitRepository keeps rate limit counters in memory, so limits only apply per
+// server instance.
+type RateLimitRepository struct {
+	windows map[rateLimitWindow]int
+	mu      *sync.RWMutex
+}
+
+func NewRateLimitRepository() *RateLimitRepository {
+	return &RateLimitRepository{windows: mockRateLimits, mu: &storeMu}
+}
+
+func (r *RateLimitRepository) Hit(ctx context.Context, key string, windowStart, previousStart time.Time) (int, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := rateLimitWindow{key: key, start: windowStart.UnixNano()}
+	r.windows[current]++
+	return r.windows[current], r.windows[rateLimitWindow{key: key, start: previousStart.UnixNano()}], nil
+}
+
+func (r *RateLimitRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for window := range r.windows {
+		if window.start < before.UnixNano() {
+			delete(r.windows, window)
+		}
+	}
+	return nil
+}
+
+func (r *RateLimitRepository) DeleteKey(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for window := range r.windows {
+		if window.key == key {
+			delete(r.windows, window)
+		}
+	}
+	return nil
+}
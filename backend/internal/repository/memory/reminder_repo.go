@@ -0,0 +1,230 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"joblog/internal/core/domain"
+)
+
+type ReminderRepository struct {
+	reminders map[string]*domain.Reminder
+	mu        *sync.RWMutex
+}
+
+func NewReminderRepository() *ReminderRepository {
+	return &ReminderRepository{reminders: mockReminders, mu: &storeMu}
+}
+
+func (r *ReminderRepository) Create(ctx context.Context, reminder *domain.Reminder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reminders[reminder.ID] = reminder
+	return nil
+}
+
+func (r *ReminderRepository) GetByID(ctx context.Context, id string) (*domain.Reminder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	reminder, ok := r.reminders[id]
+	if !ok {
+		return nil, fmt.Errorf("reminder %w", domain.ErrNotFound)
+	}
+	return reminder, nil
+}
+
+func (r *ReminderRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Reminder, error) {
+	return r.filter(func(reminder *domain.Reminder) bool {
+		return reminder.UserID == userID
+	}), nil
+}
+
+func (r *ReminderRepository) ListByApplicationID(ctx context.Context, applicationID string) ([]*domain.Reminder, error) {
+	return r.filter(func(reminder *domain.Reminder) bool {
+		return reminder.ApplicationID == applicationID
+	}), nil
+}
+
+func (r *ReminderRepository) ListDue(ctx context.Context, userID string, now time.Time) ([]*domain.Reminder, error) {
+	return r.filter(func(reminder *domain.Reminder) bool {
+		return reminder.UserID == userID && !reminder.Done && !reminder.DueAt.After(now)
+	}), nil
+}
+
+// filter returns the matching reminders ordered by due time, earliest first.
+func (r *ReminderRepository) filter(match func(*domain.Reminder) bool) []*domain.Reminder {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	reminders := []*domain.Reminder{}
+	for _, reminder := range r.reminders {
+		if match(reminder) {
+			reminders = append(reminders, reminder)
+		}
+	}
+	sortReminders(reminders)
+	return reminders
+}
+
+func (r *ReminderRepository) Update(ctx context.Context, reminder *domain.Reminder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.reminders[reminder.ID]; !ok {
+		return fmt.Errorf("reminder %w", domain.ErrNotFound)
+	}
+	r.reminders[reminder.ID] = reminder
+	return nil
+}
+
+func (r *ReminderRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.reminders[id]; !ok {
+		return fmt.Errorf("reminder %w", domain.ErrNotFound)
+	}
+	delete(r.reminders, id)
+	return nil
+}
+
+func (r *ReminderRepository) ExistsForRule(ctx context.Context, applicationID, ruleID, anchor string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, reminder := range r.reminders {
+		if reminder.ApplicationID == applicationID && reminder.RuleID == ruleID && reminder.RuleAnchor == anchor {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *ReminderRepository) ClaimUndelivered(ctx context.Context, now time.Time, limit, maxAttempts int) ([]*domain.Reminder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	due := []*domain.Reminder{}
+	for _, reminder := range r.reminders {
+		retrying := reminder.NextAttemptAt != nil && reminder.NextAttemptAt.After(now)
+		if !reminder.Done && reminder.NotifiedAt == nil && !reminder.DueAt.After(now) &&
+			reminder.DeliveryAttempts < maxAttempts && !retrying {
+			due = append(due, reminder)
+		}
+	}
+	sortReminders(due)
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	for _, reminder := range due {
+		notifiedAt := now
+		reminder.NotifiedAt = &notifiedAt
+	}
+	return due, nil
+}
+
+func (r *ReminderRepository) ReleaseClaim(ctx context.Context, id string, retryAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if reminder, ok := r.reminders[id]; ok {
+		reminder.NotifiedAt = nil
+		reminder.DeliveryAttempts++
+		reminder.NextAttemptAt = &retryAt
+	}
+	return nil
+}
+
+// sortReminders orders reminders by due time, earliest first.
+func sortReminders(reminders []*domain.Reminder) {
+	sort.Slice(reminders, func(i, j int) bool {
+		return reminders[i].DueAt.Before(reminders[j].DueAt)
+	})
+}
+
+type FollowUpRuleRepository struct {
+	rules map[string]*domain.FollowUpRule
+	mu    *sync.RWMutex
+}
+
+func NewFollowUpRuleRepository() *FollowUpRuleRepository {
+	return &FollowUpRuleRepository{rules: mockFollowUpRules, mu: &storeMu}
+}
+
+func (r *FollowUpRuleRepository) Create(ctx context.Context, rule *domain.FollowUpRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules[rule.ID] = rule
+	return nil
+}
+
+func (r *FollowUpRuleRepository) GetByID(ctx context.Context, id string) (*domain.FollowUpRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rule, ok := r.rules[id]
+	if !ok {
+		return nil, fmt.Errorf("follow-up rule %w", domain.ErrNotFound)
+	}
+	return rule, nil
+}
+
+func (r *FollowUpRuleRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.FollowUpRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rules := []*domain.FollowUpRule{}
+	for _, rule := range r.rules {
+		if rule.UserID == userID {
+			rules = append(rules, rule)
+		}
+	}
+	sortFollowUpRules(rules)
+	return rules, nil
+}
+
+func (r *FollowUpRuleRepository) ListEnabled(ctx context.Context) ([]*domain.FollowUpRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rules := []*domain.FollowUpRule{}
+	for _, rule := range r.rules {
+		if rule.Enabled {
+			rules = append(rules, rule)
+		}
+	}
+	sortFollowUpRules(rules)
+	return rules, nil
+}
+
+func (r *FollowUpRuleRepository) Update(ctx context.Context, rule *domain.FollowUpRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rules[rule.ID]; !ok {
+		return fmt.Errorf("follow-up rule %w", domain.ErrNotFound)
+	}
+	r.rules[rule.ID] = rule
+	return nil
+}
+
+// Delete removes the rule. Reminders it already created are kept, like the
+// ON DELETE SET NULL on reminders.rule_id.
+func (r *FollowUpRuleRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rules[id]; !ok {
+		return fmt.Errorf("follow-up rule %w", domain.ErrNotFound)
+	}
+	delete(r.rules, id)
+	for _, reminder := range mockReminders {
+		if reminder.RuleID == id {
+			reminder.RuleID = ""
+			reminder.RuleAnchor = ""
+		}
+	}
+	return nil
+}
+
+// sortFollowUpRules keeps rule listings stable, since maps have no order.
+func sortFollowUpRules(rules []*domain.FollowUpRule) {
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].UserID != rules[j].UserID {
+			return rules[i].UserID < rules[j].UserID
+		}
+		return rules[i].ID < rules[j].ID
+	})
+}
@@ -0,0 +1,231 @@
+package memory
+
+import (
+	"context"
+	"html"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"joblog/internal/core/domain"
+)
+
+// SearchRepository answers searches from mockSearchIndex, which the application repository
+// keeps up to date on every write. It approximates postgres' english text search: words are
+// lowercased and lightly stemmed, common words are ignored and every query word must appear
+// in the same company/role, note or history entry.
+type SearchRepository struct {
+	index *searchIndex
+	mu    *sync.RWMutex
+}
+
+func NewSearchRepository() *SearchRepository {
+	return &SearchRepository{index: mockSearchIndex, mu: &storeMu}
+}
+
+// Match weights, as in the postgres repository.
+var searchWeights = map[domain.SearchSource]float64{
+	domain.SourceApplication: 2,
+	domain.SourceNote:        1,
+	domain.SourceHistory:     0.5,
+}
+
+// searchDoc is one indexed piece of text belonging to an application.
+type searchDoc struct {
+	applicationID string
+	source        domain.SearchSource
+	noteID        string
+	position      int // Index into the application's history, for history entries
+}
+
+// searchIndex is an inverted index from stemmed terms to the documents containing them.
+type searchIndex struct {
+	postings map[string]map[searchDoc]int // Term -> document -> occurrences
+	texts    map[searchDoc]string
+	docs     map[string][]searchDoc // Application ID -> its documents
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings: make(map[string]map[searchDoc]int),
+		texts:    make(map[searchDoc]string),
+		docs:     make(map[string][]searchDoc),
+	}
+}
+
+// index replaces everything indexed for the application. storeMu must be held.
+func (idx *searchIndex) index(app *domain.Application) {
+	idx.remove(app.ID)
+
+	idx.add(searchDoc{applicationID: app.ID, source: domain.SourceApplication}, app.Company+" - "+app.Role)
+	for _, note := range app.Notes {
+		idx.add(searchDoc{applicationID: app.ID, source: domain.SourceNote, noteID: note.ID}, note.Content)
+	}
+	for i, event := range app.History {
+		text := event.Event
+		if event.Reason != "" {
+			text += ": " + event.Reason
+		}
+		idx.add(searchDoc{applicationID: app.ID, source: domain.SourceHistory, position: i}, text)
+	}
+}
+
+func (idx *searchIndex) add(doc searchDoc, text string) {
+	idx.texts[doc] = text
+	idx.docs[doc.applicationID] = append(idx.docs[doc.applicationID], doc)
+	for _, term := range searchTerms(text) {
+		if idx.postings[term] == nil {
+			idx.postings[term] = make(map[searchDoc]int)
+		}
+		idx.postings[term][doc]++
+	}
+}
+
+// remove drops every document of the application. storeMu must be held.
+func (idx *searchIndex) remove(applicationID string) {
+	for _, doc := range idx.docs[applicationID] {
+		for _, term := range searchTerms(idx.texts[doc]) {
+			delete(idx.postings[term], doc)
+			if len(idx.postings[term]) == 0 {
+				delete(idx.postings, term)
+			}
+		}
+		delete(idx.texts, doc)
+	}
+	delete(idx.docs, applicationID)
+}
+
+func (r *SearchRepository) Search(ctx context.Context, userID, query string, limit int) ([]*domain.SearchResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := []*domain.SearchResult{}
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return results, nil
+	}
+
+	// Documents containing every term, scored by how often the terms occur
+	scores := make(map[searchDoc]float64)
+	for doc, count := range r.index.postings[terms[0]] {
+		scores[doc] = float64(count)
+	}
+	for _, term := range terms[1:] {
+		for doc := range scores {
+			count, ok := r.index.postings[term][doc]
+			if !ok {
+				delete(scores, doc)
+				continue
+			}
+			scores[doc] += float64(count)
+		}
+	}
+
+	type scoredMatch struct {
+		match domain.SearchMatch
+		score float64
+	}
+	byApp := make(map[string]*domain.SearchResult)
+	matches := make(map[string][]scoredMatch)
+	for doc, score := range scores {
+		app, ok := mockApplications[doc.applicationID]
+		if !ok || app.UserID != userID || app.Status == domain.StatusArchived {
+			continue
+		}
+		score *= searchWeights[doc.source] / float64(len(searchTerms(r.index.texts[doc])))
+
+		result, ok := byApp[app.ID]
+		if !ok {
+			result = &domain.SearchResult{ApplicationID: app.ID, Company: app.Company, Role: app.Role, Status: app.Status}
+			byApp[app.ID] = result
+			results = append(results, result)
+		}
+		result.Rank += score
+		matches[app.ID] = append(matches[app.ID], scoredMatch{
+			match: domain.SearchMatch{Source: doc.source, NoteID: doc.noteID, Snippet: highlight(r.index.texts[doc], terms)},
+			score: score,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].ApplicationID < results[j].ApplicationID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	for _, result := range results {
+		found := matches[result.ApplicationID]
+		sort.SliceStable(found, func(i, j int) bool { return found[i].score > found[j].score })
+		for _, m := range found {
+			result.Matches = append(result.Matches, m.match)
+		}
+	}
+	return results, nil
+}
+
+// searchStopWords are left out of the index, like postgres' english stop words.
+var searchStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true,
+	"the": true, "to": true, "was": true, "were": true, "with": true,
+}
+
+func searchWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// searchTerm lowercases a word and strips a plural "s", so "Interviews" matches "interview".
+func searchTerm(word string) string {
+	term := strings.ToLower(word)
+	if len(term) > 3 && strings.HasSuffix(term, "s") && !strings.HasSuffix(term, "ss") {
+		term = term[:len(term)-1]
+	}
+	return term
+}
+
+func searchTerms(text string) []string {
+	var terms []string
+	for _, word := range searchWords(text) {
+		if term := searchTerm(word); !searchStopWords[term] {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// highlight HTML-escapes text and wraps the words matching any of the terms in
+// <mark></mark>, trimming long texts to a window around the first match.
+func highlight(text string, terms []string) string {
+	const window = 10
+
+	wanted := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		wanted[term] = true
+	}
+
+	words := strings.Fields(text)
+	first := -1
+	for i, word := range words {
+		words[i] = html.EscapeString(word)
+		for _, part := range searchWords(word) {
+			if wanted[searchTerm(part)] {
+				before, after, _ := strings.Cut(word, part)
+				words[i] = html.EscapeString(before) + "<mark>" + html.EscapeString(part) + "</mark>" + html.EscapeString(after)
+				if first < 0 {
+					first = i
+				}
+				break
+			}
+		}
+	}
+
+	start := max(first-window, 0)
+	end := min(start+2*window, len(words))
+	return strings.Join(words[start:end], " ")
+}
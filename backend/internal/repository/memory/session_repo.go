@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"joblog/internal/core/domain"
+)
+
+type SessionRepository struct {
+	sessions map[string]*domain.Session
+	mu       *sync.RWMutex
+}
+
+func NewSessionRepository() *SessionRepository {
+	return &SessionRepository{sessions: mockSessions, mu: &storeMu}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *session
+	r.sessions[session.ID] = &stored
+	return nil
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (*domain.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("session %w", domain.ErrNotFound)
+	}
+	found := *session
+	return &found, nil
+}
+
+func (r *SessionRepository) ListActiveByUserID(ctx context.Context, userID string) ([]*domain.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := time.Now()
+	sessions := []*domain.Session{}
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.RevokedAt == nil && session.ExpiresAt.After(now) {
+			found := *session
+			sessions = append(sessions, &found)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt) })
+	return sessions, nil
+}
+
+func (r *SessionRepository) Rotate(ctx context.Context, session *domain.Session, previousHash string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.sessions[session.ID]
+	if !ok || stored.TokenHash != previousHash || stored.RevokedAt != nil || !stored.ExpiresAt.After(time.Now()) {
+		return false, nil
+	}
+	stored.TokenHash = session.TokenHash
+	stored.PreviousTokenHashes = slices.Clone(session.PreviousTokenHashes)
+	stored.RotatedAt = session.RotatedAt
+	stored.UserAgent = session.UserAgent
+	stored.IP = session.IP
+	stored.LastUsedAt = session.LastUsedAt
+	stored.ExpiresAt = session.ExpiresAt
+	return true, nil
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if session, ok := r.sessions[id]; ok && session.RevokedAt == nil {
+		now := time.Now()
+		session.RevokedAt = &now
+	}
+	return nil
+}
+
+func (r *SessionRepository) RevokeAllByUserID(ctx context.Context, userID, exceptID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.RevokedAt == nil && session.ID != exceptID {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
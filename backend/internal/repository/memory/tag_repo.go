@@ -0,0 +1,169 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"joblog/internal/core/domain"
+)
+
+type TagRepository struct {
+	tags map[string]*domain.Tag
+	mu   *sync.RWMutex
+}
+
+func NewTagRepository() *TagRepository {
+	return &TagRepository{tags: mockTags, mu: &storeMu}
+}
+
+// nameTaken reports whether another of the user's tags already has the name, ignoring case.
+func (r *TagRepository) nameTaken(tag *domain.Tag) bool {
+	for _, existing := range r.tags {
+		if existing.ID != tag.ID && existing.UserID == tag.UserID && strings.EqualFold(existing.Name, tag.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *TagRepository) Create(ctx context.Context, tag *domain.Tag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.nameTaken(tag) {
+		return fmt.Errorf("%w: a tag named %q already exists", domain.ErrConflict, tag.Name)
+	}
+	r.tags[tag.ID] = tag
+	return nil
+}
+
+func (r *TagRepository) GetByID(ctx context.Context, id string) (*domain.Tag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tag, ok := r.tags[id]
+	if !ok {
+		return nil, fmt.Errorf("tag %w", domain.ErrNotFound)
+	}
+	return tag, nil
+}
+
+func (r *TagRepository) ListSummaries(ctx context.Context, userID string) ([]*domain.TagSummary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, tagIDs := range mockApplicationTags {
+		for _, tagID := range tagIDs {
+			counts[tagID]++
+		}
+	}
+
+	summaries := []*domain.TagSummary{}
+	for _, tag := range r.tags {
+		if tag.UserID == userID {
+			summaries = append(summaries, &domain.TagSummary{Tag: *tag, ApplicationCount: counts[tag.ID]})
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return strings.ToLower(summaries[i].Name) < strings.ToLower(summaries[j].Name)
+	})
+	return summaries, nil
+}
+
+func (r *TagRepository) Update(ctx context.Context, tag *domain.Tag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.tags[tag.ID]; !ok {
+		return fmt.Errorf("tag %w", domain.ErrNotFound)
+	}
+	if r.nameTaken(tag) {
+		return fmt.Errorf("%w: a tag named %q already exists", domain.ErrConflict, tag.Name)
+	}
+	r.tags[tag.ID] = tag
+	return nil
+}
+
+func (r *TagRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.tags[id]; !ok {
+		return fmt.Errorf("tag %w", domain.ErrNotFound)
+	}
+	delete(r.tags, id)
+	for appID := range mockApplicationTags {
+		unassignTag(appID, id)
+	}
+	return nil
+}
+
+func (r *TagRepository) Merge(ctx context.Context, sourceID, targetID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.tags[sourceID]; !ok {
+		return fmt.Errorf("tag %w", domain.ErrNotFound)
+	}
+	for appID, tagIDs := range mockApplicationTags {
+		for _, tagID := range tagIDs {
+			if tagID == sourceID {
+				unassignTag(appID, sourceID)
+				assignTag(appID, targetID)
+				break
+			}
+		}
+	}
+	delete(r.tags, sourceID)
+	return nil
+}
+
+func (r *TagRepository) Assign(ctx context.Context, applicationID, tagID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	assignTag(applicationID, tagID)
+	return nil
+}
+
+func (r *TagRepository) Unassign(ctx context.Context, applicationID, tagID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !unassignTag(applicationID, tagID) {
+		return fmt.Errorf("tag is not assigned to this application")
+	}
+	return nil
+}
+
+// assignTag and unassignTag must be called with storeMu held.
+func assignTag(applicationID, tagID string) {
+	for _, existing := range mockApplicationTags[applicationID] {
+		if existing == tagID {
+			return
+		}
+	}
+	mockApplicationTags[applicationID] = append(mockApplicationTags[applicationID], tagID)
+}
+
+func unassignTag(applicationID, tagID string) bool {
+	tagIDs := mockApplicationTags[applicationID]
+	for i, existing := range tagIDs {
+		if existing == tagID {
+			mockApplicationTags[applicationID] = append(tagIDs[:i:i], tagIDs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// tagsOf returns an application's tags ordered by name. storeMu must be held.
+func tagsOf(applicationID string) []domain.Tag {
+	tags := []domain.Tag{}
+	for _, tagID := range mockApplicationTags[applicationID] {
+		if tag, ok := mockTags[tagID]; ok {
+			tags = append(tags, *tag)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return strings.ToLower(tags[i].Name) < strings.ToLower(tags[j].Name)
+	})
+	return tags
+}
@@ -1,4 +1,3 @@
-
 package memory
 
 import (
@@ -11,12 +10,12 @@ import (
 
 type UserRepository struct {
 	users map[string]*domain.User
-	mu    sync.RWMutex
+	mu    *sync.RWMutex
 }
 
 func NewUserRepository() *UserRepository {
 	// mockUsers is initialized in mock_data.go
-	return &UserRepository{users: mockUsers}
+	return &UserRepository{users: mockUsers, mu: &storeMu}
 }
 
 func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
@@ -61,3 +60,171 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	}
 	return user, nil
 }
+
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.users[user.ID]
+	if !ok {
+		return fmt.Errorf("user not found")
+	}
+	if other, ok := r.users[user.Username]; ok && other.ID != user.ID {
+		return fmt.Errorf("%w: user with username %s already exists", domain.ErrConflict, user.Username)
+	}
+	if other, ok := r.users[user.Email]; ok && other.ID != user.ID {
+		return fmt.Errorf("%w: user with email %s already exists", domain.ErrConflict, user.Email)
+	}
+
+	delete(r.users, stored.Username)
+	delete(r.users, stored.Email)
+	stored.Username = user.Username
+	stored.Email = user.Email
+	stored.EmailVerified = user.EmailVerified
+	stored.PendingEmail = user.PendingEmail
+	r.users[stored.Username] = stored
+	r.users[stored.Email] = stored
+
+	for _, post := range mockBlogPosts {
+		if post.AuthorID == user.ID {
+			post.Author = user.Username
+		}
+		renameCommentAuthor(post.Comments, user.ID, user.Username)
+	}
+	return nil
+}
+
+// renameCommentAuthor updates the author's name on their comments, including nested replies.
+func renameCommentAuthor(comments []domain.Comment, authorID, name string) {
+	for i := range comments {
+		if comments[i].AuthorID == authorID {
+			comments[i].Author = name
+		}
+		renameCommentAuthor(comments[i].Replies, authorID, name)
+	}
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id, passwordHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return fmt.Errorf("user not found")
+	}
+	user.PasswordHash = passwordHash
+	return nil
+}
+
+func (r *UserRepository) MarkEmailVerified(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return fmt.Errorf("user not found")
+	}
+	user.EmailVerified = true
+	return nil
+}
+
+// Delete removes the user and purges their applications, blog posts and comments.
+// All in-memory repositories share storeMu, so this is atomic like the postgres transaction.
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return fmt.Errorf("user not found")
+	}
+
+	for appID, app := range mockApplications {
+		if app.UserID == id {
+			delete(mockApplications, appID)
+			delete(mockContactLinks, appID)
+			delete(mockApplicationTags, appID)
+			mockSearchIndex.remove(appID)
+		}
+	}
+	for postID, post := range mockBlogPosts {
+		if post.AuthorID == id {
+			delete(mockBlogPosts, postID)
+			continue
+		}
+		post.Comments = withoutCommentsBy(post.Comments, id)
+	}
+
+	for interviewID, interview := range mockInterviews {
+		if interview.UserID == id {
+			delete(mockInterviews, interviewID)
+		}
+	}
+	for reminderID, reminder := range mockReminders {
+		if reminder.UserID == id {
+			delete(mockReminders, reminderID)
+		}
+	}
+	for companyID, company := range mockCompanies {
+		if company.UserID == id {
+			delete(mockCompanies, companyID)
+		}
+	}
+	for contactID, contact := range mockContacts {
+		if contact.UserID == id {
+			delete(mockContacts, contactID)
+		}
+	}
+	for fieldID, field := range mockCustomFields {
+		if field.UserID == id {
+			delete(mockCustomFields, fieldID)
+		}
+	}
+	for tagID, tag := range mockTags {
+		if tag.UserID == id {
+			delete(mockTags, tagID)
+		}
+	}
+	for offerID, offer := range mockOffers {
+		if offer.UserID == id {
+			delete(mockOffers, offerID)
+		}
+	}
+	for ruleID, rule := range mockFollowUpRules {
+		if rule.UserID == id {
+			delete(mockFollowUpRules, ruleID)
+		}
+	}
+	// Lockouts and rate limits are keyed by username rather than user; DeleteAccount clears
+	// them through the LoginGuard, the same way for both stores
+	for attemptID, attempt := range mockLoginAttempts {
+		if attempt.UserID == id {
+			delete(mockLoginAttempts, attemptID)
+		}
+	}
+	for tokenID, token := range mockUserTokens {
+		if token.UserID == id {
+			delete(mockUserTokens, tokenID)
+		}
+	}
+	for sessionID, session := range mockSessions {
+		if session.UserID == id {
+			delete(mockSessions, sessionID)
+		}
+	}
+	delete(mockWorkflows, id)
+	delete(mockCalendarTokens, id)
+	delete(r.users, user.ID)
+	delete(r.users, user.Username)
+	delete(r.users, user.Email)
+	return nil
+}
+
+// withoutCommentsBy drops the author's comments, including nested replies.
+func withoutCommentsBy(comments []domain.Comment, authorID string) []domain.Comment {
+	kept := []domain.Comment{}
+	for _, comment := range comments {
+		if comment.AuthorID == authorID {
+			continue
+		}
+		comment.Replies = withoutCommentsBy(comment.Replies, authorID)
+		kept = append(kept, comment)
+	}
+	return kept
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"joblog/internal/core/domain"
+)
+
+type UserTokenRepository struct {
+	tokens map[string]*domain.UserToken
+	mu     *sync.RWMutex
+}
+
+func NewUserTokenRepository() *UserTokenRepository {
+	return &UserTokenRepository{tokens: mockUserTokens, mu: &storeMu}
+}
+
+func (r *UserTokenRepository) Create(ctx context.Context, token *domain.UserToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *token
+	r.tokens[token.ID] = &stored
+	return nil
+}
+
+func (r *UserTokenRepository) Consume(ctx context.Context, purpose domain.UserTokenPurpose, tokenHash string) (*domain.UserToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.Purpose == purpose && token.TokenHash == tokenHash && token.UsedAt == nil && token.ExpiresAt.After(now) {
+			token.UsedAt = &now
+			consumed := *token
+			return &consumed, nil
+		}
+	}
+	return nil, fmt.Errorf("token not found")
+}
+
+func (r *UserTokenRepository) InvalidateByUserID(ctx context.Context, userID string, purpose domain.UserTokenPurpose) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.Purpose == purpose && token.UsedAt == nil {
+			token.UsedAt = &now
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"joblog/internal/core/domain"
+)
+
+type WorkflowRepository struct {
+	workflows map[string]*domain.Workflow
+	mu        *sync.RWMutex
+}
+
+func NewWorkflowRepository() *WorkflowRepository {
+	return &WorkflowRepository{workflows: mockWorkflows, mu: &storeMu}
+}
+
+func (r *WorkflowRepository) GetByUserID(ctx context.Context, userID string) (*domain.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.workflows[userID], nil
+}
+
+func (r *WorkflowRepository) Save(ctx context.Context, userID string, workflow *domain.Workflow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.workflows[userID] = workflow
+	return nil
+}
+
+func (r *WorkflowRepository) Delete(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.workflows, userID)
+	return nil
+}
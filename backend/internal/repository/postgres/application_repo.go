@@ -1,18 +1,26 @@
-
 package postgres
 
 import (
 	"context"
-	"errors"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"joblog/internal/core/domain"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// dbtx is satisfied by both the pool and a transaction.
+type dbtx interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
 type ApplicationRepository struct {
 	db *pgxpool.Pool
 }
@@ -29,97 +37,458 @@ func (r *ApplicationRepository) Create(ctx context.Context, app *domain.Applicat
 	}
 	defer tx.Rollback(ctx) // Rollback is a no-op if tx is already committed
 
-	appQuery := `INSERT INTO applications (id, user_id, company, role, date, status) 
-                 VALUES ($1, $2, $3, $4, $5, $6)`
-	_, err = tx.Exec(ctx, appQuery, app.ID, app.UserID, app.Company, app.Role, app.Date, app.Status)
+	companyID, err := createApplication(ctx, tx, app)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	app.CompanyID = companyID
+	return nil
+}
+
+// createApplication inserts the application with its notes and history and returns its company ID.
+func createApplication(ctx context.Context, tx pgx.Tx, app *domain.Application) (string, error) {
+	companyID, err := resolveCompany(ctx, tx, app.UserID, app.Company)
+	if err != nil {
+		return "", err
+	}
+
+	customFields, err := encodeCustomFields(app.CustomFields)
 	if err != nil {
-		return fmt.Errorf("failed to insert application: %w", err)
+		return "", err
 	}
 
-	if len(app.History) > 0 {
-		historyQuery := `INSERT INTO history_events (application_id, event) VALUES ($1, $2)`
-		_, err := tx.Exec(ctx, historyQuery, app.ID, app.History[0].Event)
+	appQuery := `INSERT INTO applications (id, user_id, company, company_id, role, date, status,
+                     job_url, location, work_mode, salary_min, salary_max, salary_currency,
+                     employment_type, source, priority, job_description, custom_fields)
+                 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
+	args := append([]any{app.ID, app.UserID, app.Company, companyID, app.Role, app.Date, app.Status}, jobPostingArgs(&app.JobPosting)...)
+	_, err = tx.Exec(ctx, appQuery, append(args, customFields)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to insert application: %w", err)
+	}
+
+	for _, note := range app.Notes {
+		noteQuery := `INSERT INTO notes (id, application_id, content, created_at) VALUES ($1, $2, $3, $4)`
+		if _, err := tx.Exec(ctx, noteQuery, note.ID, app.ID, note.Content, note.CreatedAt); err != nil {
+			return "", fmt.Errorf("failed to insert note: %w", err)
+		}
+	}
+	if err := insertHistory(ctx, tx, app.ID, app.History); err != nil {
+		return "", err
+	}
+	return companyID, nil
+}
+
+// resolveCompany returns the ID of the user's company matching name, creating the company on first use.
+func resolveCompany(ctx context.Context, tx pgx.Tx, userID, name string) (string, error) {
+	// The no-op DO UPDATE makes RETURNING yield the existing row on conflict
+	query := `INSERT INTO companies (user_id, name, normalized_name)
+              VALUES ($1, $2, $3)
+              ON CONFLICT (user_id, normalized_name) DO UPDATE SET normalized_name = EXCLUDED.normalized_name
+              RETURNING id`
+	var id string
+	name = strings.TrimSpace(name)
+	if err := tx.QueryRow(ctx, query, userID, name, domain.NormalizeCompanyName(name)).Scan(&id); err != nil {
+		return "", fmt.Errorf("failed to resolve company: %w", err)
+	}
+	return id, nil
+}
+
+func insertHistory(ctx context.Context, tx pgx.Tx, appID string, history []domain.HistoryEvent) error {
+	histQuery := `INSERT INTO history_events (application_id, event, type, from_status, to_status, fields, reason, created_at)
+                  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	for _, event := range history {
+		fields := event.Fields
+		if fields == nil {
+			fields = []string{}
+		}
+		_, err := tx.Exec(ctx, histQuery, appID, event.Event, event.Type,
+			nullIfEmpty(string(event.FromStatus)), nullIfEmpty(string(event.ToStatus)), fields, nullIfEmpty(event.Reason), event.Date)
 		if err != nil {
-			return fmt.Errorf("failed to insert initial history event: %w", err)
+			return fmt.Errorf("failed to insert history event: %w", err)
 		}
 	}
+	return nil
+}
+
+// nullIfEmpty stores empty optional strings as NULL.
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// applicationColumns are the columns read by scanApplication. They are qualified
+// with the alias "a" so queries can join other tables.
+const applicationColumns = `a.id, a.user_id, a.company, a.company_id, a.role, a.date, a.updated_at, a.status,
+    a.job_url, a.location, a.work_mode, a.salary_min, a.salary_max, a.salary_currency,
+    a.employment_type, a.source, a.priority, a.job_description, a.custom_fields`
 
-	return tx.Commit(ctx)
+// scanApplication reads a row selected with applicationColumns, followed by any extra columns.
+// Notes and history are loaded separately.
+func scanApplication(row pgx.Row, extra ...any) (*domain.Application, error) {
+	var app domain.Application
+	var date, updatedAt time.Time
+	var customFields []byte
+	dest := []any{
+		&app.ID,
+		&app.UserID,
+		&app.Company,
+		&app.CompanyID,
+		&app.Role,
+		&date,
+		&updatedAt,
+		&app.Status,
+		&app.JobURL,
+		&app.Location,
+		&app.WorkMode,
+		&app.SalaryMin,
+		&app.SalaryMax,
+		&app.SalaryCurrency,
+		&app.EmploymentType,
+		&app.Source,
+		&app.Priority,
+		&app.JobDescription,
+		&customFields,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(customFields, &app.CustomFields); err != nil {
+		return nil, fmt.Errorf("failed to decode custom fields: %w", err)
+	}
+	app.Date = date.Format("2006-01-02")
+	app.UpdatedAt = updatedAt.Format("2006-01-02")
+	return &app, nil
+}
+
+// jobPostingArgs returns the job posting values in the column order used by Create and Update.
+func jobPostingArgs(posting *domain.JobPosting) []any {
+	return []any{
+		posting.JobURL,
+		posting.Location,
+		posting.WorkMode,
+		posting.SalaryMin,
+		posting.SalaryMax,
+		posting.SalaryCurrency,
+		posting.EmploymentType,
+		posting.Source,
+		posting.Priority,
+		posting.JobDescription,
+	}
 }
 
-func (r *ApplicationRepository) GetAllByUserID(ctx context.Context, userID string) ([]*domain.Application, error) {
-	query := `SELECT id, company, role, date, updated_at, status 
-              FROM applications 
-              WHERE user_id = $1 AND status != 'Archived' 
-              ORDER BY date DESC`
-	rows, err := r.db.Query(ctx, query, userID)
+// encodeCustomFields encodes custom field values for the JSONB column, storing nil as {}.
+func encodeCustomFields(values domain.CustomFieldValues) ([]byte, error) {
+	if values == nil {
+		values = domain.CustomFieldValues{}
+	}
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode custom fields: %w", err)
+	}
+	return encoded, nil
+}
+
+// applicationSortKeys maps each sort to a text expression whose lexical order
+// matches the column order, so it can be round-tripped through a cursor.
+var applicationSortKeys = map[domain.ApplicationSort]string{
+	domain.SortByDate:      `to_char(date, 'YYYY-MM-DD')`,
+	domain.SortByUpdatedAt: `to_char(updated_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US')`,
+	domain.SortByCompany:   `lower(company)`,
+}
+
+// List returns one page of applications using keyset pagination on (sort key, id).
+func (r *ApplicationRepository) List(ctx context.Context, userID string, filter domain.ApplicationFilter) (*domain.ApplicationPage, error) {
+	sortKey, ok := applicationSortKeys[filter.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sort field %q", filter.Sort)
+	}
+
+	args := []any{userID}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := []string{"user_id = $1"}
+	if !filter.IncludeArchived {
+		conditions = append(conditions, "status != 'Archived'")
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		conditions = append(conditions, "status = ANY("+arg(statuses)+"::text[])")
+	}
+	if filter.Company != "" {
+		conditions = append(conditions, "company ILIKE "+arg("%"+escapeLike(filter.Company)+"%"))
+	}
+	if filter.Role != "" {
+		conditions = append(conditions, "role ILIKE "+arg("%"+escapeLike(filter.Role)+"%"))
+	}
+	if filter.DateFrom != "" {
+		conditions = append(conditions, "date >= "+arg(filter.DateFrom)+"::date")
+	}
+	if filter.DateTo != "" {
+		conditions = append(conditions, "date <= "+arg(filter.DateTo)+"::date")
+	}
+	if filter.UpdatedBefore != "" {
+		conditions = append(conditions, "updated_at < "+arg(filter.UpdatedBefore)+"::date")
+	}
+	if len(filter.Tags) > 0 {
+		names := make([]string, len(filter.Tags))
+		for i, name := range filter.Tags {
+			names[i] = strings.ToLower(name)
+		}
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM application_tags at JOIN tags t ON t.id = at.tag_id
+                                                 WHERE at.application_id = a.id AND lower(t.name) = ANY(`+arg(names)+`::text[]))`)
+	}
+	if len(filter.CustomFields) > 0 {
+		// Containment is served by the GIN index on custom_fields
+		values, err := encodeCustomFields(filter.CustomFields)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, "custom_fields @> "+arg(values)+"::jsonb")
+	}
+
+	direction, comparison := "ASC", ">"
+	if filter.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if filter.Cursor != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, %s::uuid)",
+			sortKey, comparison, arg(filter.Cursor.Key), arg(filter.Cursor.ID)))
+	}
+
+	// Fetch one extra row to know whether there is a next page
+	query := fmt.Sprintf(`SELECT `+applicationColumns+`, %s
+              FROM applications a
+              WHERE %s
+              ORDER BY %s %s, id %s
+              LIMIT %s`,
+		sortKey, strings.Join(conditions, " AND "), sortKey, direction, direction, arg(filter.Limit+1))
+
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query applications: %w", err)
 	}
 	defer rows.Close()
 
-	var apps []*domain.Application
+	page := &domain.ApplicationPage{Applications: []*domain.Application{}}
+	var lastKey string
 	for rows.Next() {
-		var app domain.Application
-		var updatedAt time.Time
-		if err := rows.Scan(&app.ID, &app.Company, &app.Role, &app.Date, &updatedAt, &app.Status); err != nil {
+		var key string
+		app, err := scanApplication(rows, &key)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan application row: %w", err)
 		}
-		app.UpdatedAt = updatedAt.Format("2006-01-02")
-		apps = append(apps, &app)
+		if len(page.Applications) == filter.Limit {
+			page.NextCursor = &domain.ApplicationCursor{
+				Sort:       filter.Sort,
+				Descending: filter.Descending,
+				Key:        lastKey,
+				ID:         page.Applications[len(page.Applications)-1].ID,
+			}
+			break
+		}
+		page.Applications = append(page.Applications, app)
+		lastKey = key
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating application rows: %w", err)
 	}
 
-	return apps, nil
+	if err := loadTags(ctx, r.db, page.Applications); err != nil {
+		return nil, err
+	}
+	if filter.WithDetails {
+		if err := loadNotesAndHistory(ctx, r.db, page.Applications); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
 }
 
-func (r *ApplicationRepository) GetByID(ctx context.Context, id string) (*domain.Application, error) {
-	var app domain.Application
-	var updatedAt time.Time
+// escapeLike escapes the LIKE wildcards so user input is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
 
+func (r *ApplicationRepository) GetByID(ctx context.Context, id string) (*domain.Application, error) {
 	// 1. Fetch main application
-	queryApp := `SELECT id, user_id, company, role, date, updated_at, status FROM applications WHERE id = $1`
-	err := r.db.QueryRow(ctx, queryApp, id).Scan(&app.ID, &app.UserID, &app.Company, &app.Role, &app.Date, &updatedAt, &app.Status)
+	queryApp := `SELECT ` + applicationColumns + ` FROM applications a WHERE a.id = $1`
+	app, err := scanApplication(r.db.QueryRow(ctx, queryApp, id))
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("application not found")
+		if isNoRows(err) {
+			return nil, fmt.Errorf("application %w", domain.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get application: %w", err)
 	}
-	app.UpdatedAt = updatedAt.Format("2006-01-02")
 
-	// 2. Fetch notes
-	queryNotes := `SELECT id, content, created_at FROM notes WHERE application_id = $1 ORDER BY created_at ASC`
-	rowsNotes, err := r.db.Query(ctx, queryNotes, id)
+	// 2. Fetch notes, history and tags
+	if err := loadNotesAndHistory(ctx, r.db, []*domain.Application{app}); err != nil {
+		return nil, err
+	}
+	if err := loadTags(ctx, r.db, []*domain.Application{app}); err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// ListByCompanyID returns every application to the company, newest first, with notes, history and tags.
+func (r *ApplicationRepository) ListByCompanyID(ctx context.Context, companyID string) ([]*domain.Application, error) {
+	query := `SELECT ` + applicationColumns + ` FROM applications a WHERE a.company_id = $1 ORDER BY a.date DESC, a.id DESC`
+	apps, err := r.list(ctx, query, companyID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get notes: %w", err)
+		return nil, err
+	}
+	if err := loadNotesAndHistory(ctx, r.db, apps); err != nil {
+		return nil, err
+	}
+	if err := loadTags(ctx, r.db, apps); err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
+func (r *ApplicationRepository) FindByCompanyName(ctx context.Context, userID, normalizedName string) ([]*domain.Application, error) {
+	query := `SELECT ` + applicationColumns + `
+              FROM applications a
+              JOIN companies c ON c.id = a.company_id
+              WHERE c.user_id = $1 AND c.normalized_name = $2
+              ORDER BY a.date DESC, a.id DESC`
+	return r.list(ctx, query, userID, normalizedName)
+}
+
+// list runs a query selecting applicationColumns, without loading notes or history.
+func (r *ApplicationRepository) list(ctx context.Context, query string, args ...any) ([]*domain.Application, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applications: %w", err)
+	}
+	defer rows.Close()
+
+	apps := []*domain.Application{}
+	for rows.Next() {
+		app, err := scanApplication(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan application row: %w", err)
+		}
+		apps = append(apps, app)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating application rows: %w", err)
+	}
+	return apps, nil
+}
+
+// loadNotesAndHistory fills in the notes and history of the given applications with one query each.
+func loadNotesAndHistory(ctx context.Context, db dbtx, apps []*domain.Application) error {
+	if len(apps) == 0 {
+		return nil
+	}
+	byID := make(map[string]*domain.Application, len(apps))
+	ids := make([]string, len(apps))
+	for i, app := range apps {
+		byID[app.ID] = app
+		ids[i] = app.ID
+	}
+
+	queryNotes := `SELECT application_id, id, content, created_at FROM notes
+                   WHERE application_id = ANY($1::uuid[]) ORDER BY created_at ASC`
+	rowsNotes, err := db.Query(ctx, queryNotes, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get notes: %w", err)
 	}
 	defer rowsNotes.Close()
 
 	for rowsNotes.Next() {
+		var appID string
 		var note domain.Note
-		if err := rowsNotes.Scan(&note.ID, &note.Content, &note.CreatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan note: %w", err)
+		if err := rowsNotes.Scan(&appID, &note.ID, &note.Content, &note.CreatedAt); err != nil {
+			return fmt.Errorf("failed to scan note: %w", err)
 		}
-		app.Notes = append(app.Notes, note)
+		byID[appID].Notes = append(byID[appID].Notes, note)
+	}
+	if err := rowsNotes.Err(); err != nil {
+		return fmt.Errorf("error iterating notes: %w", err)
 	}
 
-	// 3. Fetch history
-	queryHistory := `SELECT event, created_at FROM history_events WHERE application_id = $1 ORDER BY created_at ASC`
-	rowsHistory, err := r.db.Query(ctx, queryHistory, id)
+	queryHistory := `SELECT application_id, event, type, from_status, to_status, fields, COALESCE(reason, ''), created_at
+                     FROM history_events WHERE application_id = ANY($1::uuid[]) ORDER BY created_at ASC`
+	rowsHistory, err := db.Query(ctx, queryHistory, ids)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get history: %w", err)
+		return fmt.Errorf("failed to get history: %w", err)
 	}
 	defer rowsHistory.Close()
 
 	for rowsHistory.Next() {
+		var appID string
 		var event domain.HistoryEvent
-		if err := rowsHistory.Scan(&event.Event, &event.Date); err != nil {
-			return nil, fmt.Errorf("failed to scan history event: %w", err)
+		var fromStatus, toStatus *string
+		if err := rowsHistory.Scan(&appID, &event.Event, &event.Type, &fromStatus, &toStatus, &event.Fields, &event.Reason, &event.Date); err != nil {
+			return fmt.Errorf("failed to scan history event: %w", err)
+		}
+		if fromStatus != nil {
+			event.FromStatus = domain.ApplicationStatus(*fromStatus)
 		}
-		app.History = append(app.History, event)
+		if toStatus != nil {
+			event.ToStatus = domain.ApplicationStatus(*toStatus)
+		}
+		byID[appID].History = append(byID[appID].History, event)
+	}
+	if err := rowsHistory.Err(); err != nil {
+		return fmt.Errorf("error iterating history: %w", err)
 	}
 
-	return &app, nil
+	return nil
+}
+
+// loadTags fills in the tags of the given applications, ordered by name.
+func loadTags(ctx context.Context, db dbtx, apps []*domain.Application) error {
+	if len(apps) == 0 {
+		return nil
+	}
+	byID := make(map[string]*domain.Application, len(apps))
+	ids := make([]string, len(apps))
+	for i, app := range apps {
+		app.Tags = []domain.Tag{}
+		byID[app.ID] = app
+		ids[i] = app.ID
+	}
+
+	query := `SELECT at.application_id, t.id, t.user_id, t.name, t.color
+              FROM application_tags at
+              JOIN tags t ON t.id = at.tag_id
+              WHERE at.application_id = ANY($1::uuid[])
+              ORDER BY lower(t.name)`
+	rows, err := db.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var appID string
+		var tag domain.Tag
+		if err := rows.Scan(&appID, &tag.ID, &tag.UserID, &tag.Name, &tag.Color); err != nil {
+			return fmt.Errorf("failed to scan tag: %w", err)
+		}
+		byID[appID].Tags = append(byID[appID].Tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating tags: %w", err)
+	}
+	return nil
 }
 
 // Update uses a transaction to update application and potentially add notes/history.
@@ -130,42 +499,264 @@ func (r *ApplicationRepository) Update(ctx context.Context, app *domain.Applicat
 	}
 	defer tx.Rollback(ctx)
 
+	companyID, err := updateApplication(ctx, tx, app)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	app.CompanyID = companyID
+	return nil
+}
+
+// ApplyBatch writes a bulk operation in one transaction.
+func (r *ApplicationRepository) ApplyBatch(ctx context.Context, batch domain.ApplicationBatch) error {
+	return r.LockAndApplyBatch(ctx, nil, func([]*domain.Application) (domain.ApplicationBatch, error) {
+		return batch, nil
+	})
+}
+
+func (r *ApplicationRepository) LockAndApplyBatch(ctx context.Context, ids []string, plan func([]*domain.Application) (domain.ApplicationBatch, error)) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	apps, err := lockApplications(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+	batch, err := plan(apps)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range batch.Tags {
+		if err := insertTag(ctx, tx, tag); err != nil {
+			return err
+		}
+	}
+	for _, field := range batch.CustomFields {
+		if err := insertCustomField(ctx, tx, field); err != nil {
+			return err
+		}
+	}
+	createdCompanyIDs := make([]string, len(batch.Creates))
+	for i, app := range batch.Creates {
+		if createdCompanyIDs[i], err = createApplication(ctx, tx, app); err != nil {
+			return err
+		}
+	}
+	companyIDs := make([]string, len(batch.Updates))
+	for i, app := range batch.Updates {
+		if companyIDs[i], err = updateApplication(ctx, tx, app); err != nil {
+			return err
+		}
+	}
+	if len(batch.Deletes) > 0 {
+		result, err := tx.Exec(ctx, `DELETE FROM applications WHERE id = ANY($1::uuid[])`, batch.Deletes)
+		if err != nil {
+			return fmt.Errorf("failed to delete applications: %w", err)
+		}
+		if result.RowsAffected() != int64(len(batch.Deletes)) {
+			return fmt.Errorf("application %w", domain.ErrNotFound)
+		}
+	}
+	for _, assignment := range batch.TagAssignments {
+		query := `INSERT INTO application_tags (application_id, tag_id) VALUES ($1, $2)
+                  ON CONFLICT (application_id, tag_id) DO NOTHING`
+		if _, err := tx.Exec(ctx, query, assignment.ApplicationID, assignment.TagID); err != nil {
+			return fmt.Errorf("failed to assign tag: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	for i, app := range batch.Creates {
+		app.CompanyID = createdCompanyIDs[i]
+	}
+	for i, app := range batch.Updates {
+		app.CompanyID = companyIDs[i]
+	}
+	return nil
+}
+
+// lockApplications selects the applications with the given IDs FOR UPDATE, with their
+// tags, notes and history. IDs that aren't UUIDs can't exist and are skipped.
+func lockApplications(ctx context.Context, tx pgx.Tx, ids []string) ([]*domain.Application, error) {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if uuid.Validate(id) == nil {
+			valid = append(valid, id)
+		}
+	}
+	if len(valid) == 0 {
+		return []*domain.Application{}, nil
+	}
+
+	query := `SELECT ` + applicationColumns + ` FROM applications a WHERE a.id = ANY($1::uuid[]) FOR UPDATE`
+	rows, err := tx.Query(ctx, query, valid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock applications: %w", err)
+	}
+	apps := []*domain.Application{}
+	for rows.Next() {
+		app, err := scanApplication(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan application row: %w", err)
+		}
+		apps = append(apps, app)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating application rows: %w", err)
+	}
+
+	if err := loadNotesAndHistory(ctx, tx, apps); err != nil {
+		return nil, err
+	}
+	if err := loadTags(ctx, tx, apps); err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
+// updateApplication writes the application, replacing its notes and history, and returns its company ID.
+func updateApplication(ctx context.Context, tx pgx.Tx, app *domain.Application) (string, error) {
+	// The company may have been renamed to (or away from) one the user already has
+	companyID, err := resolveCompany(ctx, tx, app.UserID, app.Company)
+	if err != nil {
+		return "", err
+	}
+
 	// Update main application record
-	appQuery := `UPDATE applications SET company=$1, role=$2, date=$3, status=$4, updated_at=NOW() WHERE id=$5`
-	_, err = tx.Exec(ctx, appQuery, app.Company, app.Role, app.Date, app.Status, app.ID)
+	appQuery := `UPDATE applications
+                 SET company=$1, company_id=$2, role=$3, date=$4, status=$5,
+                     job_url=$6, location=$7, work_mode=$8, salary_min=$9, salary_max=$10, salary_currency=$11,
+                     employment_type=$12, source=$13, priority=$14, job_description=$15, custom_fields=$16, updated_at=NOW()
+                 WHERE id=$17`
+	customFields, err := encodeCustomFields(app.CustomFields)
+	if err != nil {
+		return "", err
+	}
+	args := []any{app.Company, companyID, app.Role, app.Date, app.Status}
+	args = append(args, jobPostingArgs(&app.JobPosting)...)
+	_, err = tx.Exec(ctx, appQuery, append(args, customFields, app.ID)...)
 	if err != nil {
-		return fmt.Errorf("failed to update application: %w", err)
+		return "", fmt.Errorf("failed to update application: %w", err)
 	}
 
 	// Clear and re-insert notes and history (simplest approach for full updates)
 	// A more optimized approach would be to diff the collections.
 	if _, err := tx.Exec(ctx, "DELETE FROM notes WHERE application_id = $1", app.ID); err != nil {
-		return err
+		return "", err
 	}
 	if _, err := tx.Exec(ctx, "DELETE FROM history_events WHERE application_id = $1", app.ID); err != nil {
-		return err
+		return "", err
 	}
 
 	for _, note := range app.Notes {
 		noteQuery := `INSERT INTO notes (id, application_id, content, created_at) VALUES ($1, $2, $3, $4)`
 		_, err := tx.Exec(ctx, noteQuery, note.ID, app.ID, note.Content, note.CreatedAt)
 		if err != nil {
-			return fmt.Errorf("failed to insert note: %w", err)
+			return "", fmt.Errorf("failed to insert note: %w", err)
 		}
 	}
-	for _, event := range app.History {
-		histQuery := `INSERT INTO history_events (application_id, event, created_at) VALUES ($1, $2, $3)`
-		_, err := tx.Exec(ctx, histQuery, app.ID, event.Event, event.Date)
-		if err != nil {
-			return fmt.Errorf("failed to insert history event: %w", err)
-		}
+	if err := insertHistory(ctx, tx, app.ID, app.History); err != nil {
+		return "", err
 	}
-
-	return tx.Commit(ctx)
+	return companyID, nil
 }
 
+// Delete permanently removes an application. Notes and history events are
+// removed by the ON DELETE CASCADE foreign keys.
 func (r *ApplicationRepository) Delete(ctx context.Context, id string) error {
-	// The service layer handles this by calling Update with status="Archived"
-	// This method is here to satisfy the interface but is not used directly.
+	tag, err := r.db.Exec(ctx, `DELETE FROM applications WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete application: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("application %w", domain.ErrNotFound)
+	}
 	return nil
 }
+
+// GetStatsByUserID computes the dashboard statistics with SQL aggregates.
+func (r *ApplicationRepository) GetStatsByUserID(ctx context.Context, userID string) (*domain.ApplicationStats, error) {
+	stats := &domain.ApplicationStats{
+		StatusBreakdown:     make(map[domain.ApplicationStatus]int),
+		ApplicationsPerWeek: []domain.WeeklyCount{},
+	}
+
+	responded := make([]string, len(domain.RespondedStatuses))
+	for i, status := range domain.RespondedStatuses {
+		responded[i] = string(status)
+	}
+
+	// 1. Totals and response rate
+	totalsQuery := `SELECT COUNT(*),
+                           COUNT(*) FILTER (WHERE status != 'Archived'),
+                           COALESCE(
+                               COUNT(*) FILTER (WHERE status = ANY($2::text[]))::float8
+                                   / NULLIF(COUNT(*) FILTER (WHERE status != 'Archived'), 0),
+                               0)
+                    FROM applications
+                    WHERE user_id = $1`
+	err := r.db.QueryRow(ctx, totalsQuery, userID, responded).Scan(&stats.Total, &stats.Active, &stats.ResponseRate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query application totals: %w", err)
+	}
+
+	// 2. Per-status breakdown
+	rowsStatus, err := r.db.Query(ctx, `SELECT status, COUNT(*) FROM applications WHERE user_id = $1 GROUP BY status`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status breakdown: %w", err)
+	}
+	defer rowsStatus.Close()
+
+	for rowsStatus.Next() {
+		var status domain.ApplicationStatus
+		var count int
+		if err := rowsStatus.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan status breakdown: %w", err)
+		}
+		stats.StatusBreakdown[status] = count
+	}
+	if err := rowsStatus.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating status breakdown: %w", err)
+	}
+
+	// 3. Applications per week (weeks start on Monday)
+	weeklyQuery := `SELECT date_trunc('week', date)::date AS week_start, COUNT(*)
+                    FROM applications
+                    WHERE user_id = $1
+                    GROUP BY week_start
+                    ORDER BY week_start ASC`
+	rowsWeekly, err := r.db.Query(ctx, weeklyQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applications per week: %w", err)
+	}
+	defer rowsWeekly.Close()
+
+	for rowsWeekly.Next() {
+		var weekStart time.Time
+		var count int
+		if err := rowsWeekly.Scan(&weekStart, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan weekly count: %w", err)
+		}
+		stats.ApplicationsPerWeek = append(stats.ApplicationsPerWeek, domain.WeeklyCount{
+			WeekStart: weekStart.Format("2006-01-02"),
+			Count:     count,
+		})
+	}
+	if err := rowsWeekly.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating weekly counts: %w", err)
+	}
+
+	return stats, nil
+}
@@ -25,15 +25,16 @@ func NewBlogRepository(db *pgxpool.Pool) *BlogRepository {
 func (r *BlogRepository) Create(ctx context.Context, post *domain.BlogPost) error {
 	query := `
         INSERT INTO blog_posts (
-            id, slug, title, content, author_name, author_avatar_url, 
+            id, slug, title, content, author_id, author_name, author_avatar_url, 
             cover_image_url, is_public, likes, created_at
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 
 	_, err := r.db.Exec(ctx, query,
 		post.ID,
 		post.Slug,
 		post.Title,
 		post.Content,
+		post.AuthorID,
 		post.Author,
 		post.AuthorAvatar,
 		post.CoverImage,
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CalendarTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCalendarTokenRepository(db *pgxpool.Pool) *CalendarTokenRepository {
+	return &CalendarTokenRepository{db: db}
+}
+
+func (r *CalendarTokenRepository) Save(ctx context.Context, userID, tokenHash string) error {
+	query := `INSERT INTO calendar_tokens (user_id, token_hash) VALUES ($1, $2)
+              ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = NOW()`
+	if _, err := r.db.Exec(ctx, query, userID, tokenHash); err != nil {
+		return fmt.Errorf("failed to save calendar token: %w", err)
+	}
+	return nil
+}
+
+func (r *CalendarTokenRepository) GetUserIDByTokenHash(ctx context.Context, tokenHash string) (string, error) {
+	var userID string
+	err := r.db.QueryRow(ctx, `SELECT user_id FROM calendar_tokens WHERE token_hash = $1`, tokenHash).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("calendar token not found")
+		}
+		return "", fmt.Errorf("failed to get calendar token: %w", err)
+	}
+	return userID, nil
+}
+
+func (r *CalendarTokenRepository) Delete(ctx context.Context, userID string) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM calendar_tokens WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete calendar token: %w", err)
+	}
+	return nil
+}
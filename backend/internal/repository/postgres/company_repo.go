@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"joblog/internal/core/domain"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CompanyRepository implements the domain.CompanyRepository interface using PostgreSQL.
+type CompanyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCompanyRepository(db *pgxpool.Pool) *CompanyRepository {
+	return &CompanyRepository{db: db}
+}
+
+const companyColumns = `c.id, c.user_id, c.name, c.normalized_name, c.website, c.industry, c.size, c.location, c.notes,
+    c.created_at, c.updated_at`
+
+func companyDest(company *domain.Company) []any {
+	return []any{
+		&company.ID,
+		&company.UserID,
+		&company.Name,
+		&company.NormalizedName,
+		&company.Website,
+		&company.Industry,
+		&company.Size,
+		&company.Location,
+		&company.Notes,
+		&company.CreatedAt,
+		&company.UpdatedAt,
+	}
+}
+
+func (r *CompanyRepository) GetByID(ctx context.Context, id string) (*domain.Company, error) {
+	var company domain.Company
+	query := `SELECT ` + companyColumns + ` FROM companies c WHERE c.id = $1`
+	if err := r.db.QueryRow(ctx, query, id).Scan(companyDest(&company)...); err != nil {
+		if isNoRows(err) {
+			return nil, fmt.Errorf("company %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	return &company, nil
+}
+
+// ListSummaries returns the user's companies by name, each with a rollup of its applications.
+func (r *CompanyRepository) ListSummaries(ctx context.Context, userID string) ([]*domain.CompanySummary, error) {
+	query := `SELECT ` + companyColumns + `,
+                     COALESCE(s.total, 0), COALESCE(s.breakdown, '{}'::jsonb), COALESCE(s.last_applied, '')
+              FROM companies c
+              LEFT JOIN (
+                  SELECT company_id, SUM(n)::int AS total, jsonb_object_agg(status, n) AS breakdown,
+                         to_char(MAX(last_applied), 'YYYY-MM-DD') AS last_applied
+                  FROM (
+                      SELECT company_id, status, COUNT(*) AS n, MAX(date) AS last_applied
+                      FROM applications
+                      WHERE user_id = $1
+                      GROUP BY company_id, status
+                  ) per_status
+                  GROUP BY company_id
+              ) s ON s.company_id = c.id
+              WHERE c.user_id = $1
+              ORDER BY lower(c.name) ASC, c.id ASC`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query companies: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := []*domain.CompanySummary{}
+	for rows.Next() {
+		var summary domain.CompanySummary
+		dest := append(companyDest(&summary.Company), &summary.ApplicationCount, &summary.StatusBreakdown, &summary.LastAppliedOn)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan company row: %w", err)
+		}
+		summaries = append(summaries, &summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating company rows: %w", err)
+	}
+	return summaries, nil
+}
+
+func (r *CompanyRepository) Update(ctx context.Context, company *domain.Company) error {
+	query := `UPDATE companies
+              SET name=$1, normalized_name=$2, website=$3, industry=$4, size=$5, location=$6, notes=$7, updated_at=$8
+              WHERE id=$9`
+	tag, err := r.db.Exec(ctx, query,
+		company.Name,
+		company.NormalizedName,
+		company.Website,
+		company.Industry,
+		company.Size,
+		company.Location,
+		company.Notes,
+		company.UpdatedAt,
+		company.ID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("%w: another company is already named %q", domain.ErrConflict, company.Name)
+		}
+		return fmt.Errorf("failed to update company: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("company %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *CompanyRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM companies WHERE id = $1`, id)
+	if err != nil {
+		// applications.company_id references the company
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%w: the company still has applications", domain.ErrConflict)
+		}
+		return fmt.Errorf("failed to delete company: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("company %w", domain.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"joblog/internal/core/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ContactRepository implements the domain.ContactRepository interface using PostgreSQL.
+type ContactRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewContactRepository(db *pgxpool.Pool) *ContactRepository {
+	return &ContactRepository{db: db}
+}
+
+const contactColumns = `id, user_id, name, email, phone, linkedin_url, company, title, notes, created_at, updated_at`
+
+func contactDest(contact *domain.Contact) []any {
+	return []any{
+		&contact.ID,
+		&contact.UserID,
+		&contact.Name,
+		&contact.Email,
+		&contact.Phone,
+		&contact.LinkedInURL,
+		&contact.Company,
+		&contact.Title,
+		&contact.Notes,
+		&contact.CreatedAt,
+		&contact.UpdatedAt,
+	}
+}
+
+func (r *ContactRepository) Create(ctx context.Context, contact *domain.Contact) error {
+	query := `INSERT INTO contacts (` + contactColumns + `)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err := r.db.Exec(ctx, query,
+		contact.ID,
+		contact.UserID,
+		contact.Name,
+		contact.Email,
+		contact.Phone,
+		contact.LinkedInURL,
+		contact.Company,
+		contact.Title,
+		contact.Notes,
+		contact.CreatedAt,
+		contact.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create contact: %w", err)
+	}
+	return nil
+}
+
+func (r *ContactRepository) GetByID(ctx context.Context, id string) (*domain.Contact, error) {
+	var contact domain.Contact
+	query := `SELECT ` + contactColumns + ` FROM contacts WHERE id = $1`
+	if err := r.db.QueryRow(ctx, query, id).Scan(contactDest(&contact)...); err != nil {
+		if isNoRows(err) {
+			return nil, fmt.Errorf("contact %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get contact: %w", err)
+	}
+	return &contact, nil
+}
+
+func (r *ContactRepository) List(ctx context.Context, userID, query string) ([]*domain.Contact, error) {
+	sql := `SELECT ` + contactColumns + ` FROM contacts WHERE user_id = $1`
+	args := []any{userID}
+	if query != "" {
+		sql += ` AND (name ILIKE $2 OR email ILIKE $2 OR company ILIKE $2 OR title ILIKE $2)`
+		args = append(args, "%"+escapeLike(query)+"%")
+	}
+	sql += ` ORDER BY lower(name) ASC, id ASC`
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contacts: %w", err)
+	}
+	defer rows.Close()
+
+	contacts := []*domain.Contact{}
+	for rows.Next() {
+		var contact domain.Contact
+		if err := rows.Scan(contactDest(&contact)...); err != nil {
+			return nil, fmt.Errorf("failed to scan contact row: %w", err)
+		}
+		contacts = append(contacts, &contact)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating contact rows: %w", err)
+	}
+	return contacts, nil
+}
+
+func (r *ContactRepository) Update(ctx context.Context, contact *domain.Contact) error {
+	query := `UPDATE contacts
+              SET name=$1, email=$2, phone=$3, linkedin_url=$4, company=$5, title=$6, notes=$7, updated_at=$8
+              WHERE id=$9`
+	tag, err := r.db.Exec(ctx, query,
+		contact.Name,
+		contact.Email,
+		contact.Phone,
+		contact.LinkedInURL,
+		contact.Company,
+		contact.Title,
+		contact.Notes,
+		contact.UpdatedAt,
+		contact.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update contact: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("contact %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *ContactRepository) Delete(ctx context.Context, id string) error {
+	// application_contacts rows go with it via ON DELETE CASCADE
+	tag, err := r.db.Exec(ctx, `DELETE FROM contacts WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete contact: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("contact %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *ContactRepository) Link(ctx context.Context, applicationID, contactID string, role domain.ContactRole) error {
+	query := `INSERT INTO application_contacts (application_id, contact_id, role)
+              VALUES ($1, $2, $3)
+              ON CONFLICT (application_id, contact_id) DO UPDATE SET role = EXCLUDED.role`
+	if _, err := r.db.Exec(ctx, query, applicationID, contactID, role); err != nil {
+		return fmt.Errorf("failed to link contact: %w", err)
+	}
+	return nil
+}
+
+func (r *ContactRepository) Unlink(ctx context.Context, applicationID, contactID string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM application_contacts WHERE application_id = $1 AND contact_id = $2`, applicationID, contactID)
+	if err != nil {
+		return fmt.Errorf("failed to unlink contact: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("contact is not linked to this application")
+	}
+	return nil
+}
+
+func (r *ContactRepository) ListByApplicationID(ctx context.Context, applicationID string) ([]domain.ApplicationContact, error) {
+	query := `SELECT c.id, c.user_id, c.name, c.email, c.phone, c.linkedin_url, c.company, c.title, c.notes,
+                     c.created_at, c.updated_at, ac.role
+              FROM application_contacts ac
+              JOIN contacts c ON c.id = ac.contact_id
+              WHERE ac.application_id = $1
+              ORDER BY ac.created_at ASC, lower(c.name) ASC`
+	rows, err := r.db.Query(ctx, query, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query application contacts: %w", err)
+	}
+	defer rows.Close()
+
+	contacts := []domain.ApplicationContact{}
+	for rows.Next() {
+		var contact domain.ApplicationContact
+		if err := rows.Scan(append(contactDest(&contact.Contact), &contact.Role)...); err != nil {
+			return nil, fmt.Errorf("failed to scan application contact row: %w", err)
+		}
+		contacts = append(contacts, contact)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating application contact rows: %w", err)
+	}
+	return contacts, nil
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"joblog/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CustomFieldRepository implements the domain.CustomFieldRepository interface using PostgreSQL.
+// The values themselves live in the applications.custom_fields JSONB column.
+type CustomFieldRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCustomFieldRepository(db *pgxpool.Pool) *CustomFieldRepository {
+	return &CustomFieldRepository{db: db}
+}
+
+const customFieldColumns = `id, user_id, key, name, type, options, created_at, updated_at`
+
+func scanCustomField(row pgx.Row) (*domain.CustomField, error) {
+	var field domain.CustomField
+	err := row.Scan(
+		&field.ID,
+		&field.UserID,
+		&field.Key,
+		&field.Name,
+		&field.Type,
+		&field.Options,
+		&field.CreatedAt,
+		&field.UpdatedAt,
+	)
+	return &field, err
+}
+
+func (r *CustomFieldRepository) Create(ctx context.Context, field *domain.CustomField) error {
+	return insertCustomField(ctx, r.db, field)
+}
+
+// insertCustomField is shared with ApplicationRepository.ApplyBatch, which creates fields in its transaction.
+func insertCustomField(ctx context.Context, db dbtx, field *domain.CustomField) error {
+	query := `INSERT INTO custom_fields (` + customFieldColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := db.Exec(ctx, query,
+		field.ID,
+		field.UserID,
+		field.Key,
+		field.Name,
+		field.Type,
+		field.Options,
+		field.CreatedAt,
+		field.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("%w: a custom field with key %q already exists", domain.ErrConflict, field.Key)
+		}
+		return fmt.Errorf("failed to create custom field: %w", err)
+	}
+	return nil
+}
+
+func (r *CustomFieldRepository) GetByID(ctx context.Context, id string) (*domain.CustomField, error) {
+	query := `SELECT ` + customFieldColumns + ` FROM custom_fields WHERE id = $1`
+	field, err := scanCustomField(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if isNoRows(err) {
+			return nil, fmt.Errorf("custom field %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get custom field: %w", err)
+	}
+	return field, nil
+}
+
+func (r *CustomFieldRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.CustomField, error) {
+	query := `SELECT ` + customFieldColumns + ` FROM custom_fields WHERE user_id = $1 ORDER BY created_at ASC`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query custom fields: %w", err)
+	}
+	defer rows.Close()
+
+	fields := []*domain.CustomField{}
+	for rows.Next() {
+		field, err := scanCustomField(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan custom field row: %w", err)
+		}
+		fields = append(fields, field)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating custom field rows: %w", err)
+	}
+	return fields, nil
+}
+
+func (r *CustomFieldRepository) Update(ctx context.Context, field *domain.CustomField) error {
+	query := `UPDATE custom_fields SET name=$1, options=$2, updated_at=$3 WHERE id=$4`
+	result, err := r.db.Exec(ctx, query, field.Name, field.Options, field.UpdatedAt, field.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update custom field: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("custom field %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *CustomFieldRepository) Delete(ctx context.Context, id string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var userID, key string
+	err = tx.QueryRow(ctx, `DELETE FROM custom_fields WHERE id = $1 RETURNING user_id, key`, id).Scan(&userID, &key)
+	if err != nil {
+		if isNoRows(err) {
+			return fmt.Errorf("custom field %w", domain.ErrNotFound)
+		}
+		return fmt.Errorf("failed to delete custom field: %w", err)
+	}
+
+	query := `UPDATE applications SET custom_fields = custom_fields - $2
+              WHERE user_id = $1 AND custom_fields ? $2`
+	if _, err := tx.Exec(ctx, query, userID, key); err != nil {
+		return fmt.Errorf("failed to clear custom field values: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,15 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// isNoRows reports whether a lookup by ID found nothing. An ID that isn't a valid UUID
+// can't match any row, so postgres rejecting it counts the same.
+func isNoRows(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == "22P02")
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"joblog/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// InterviewRepository implements the domain.InterviewRepository interface using PostgreSQL.
+type InterviewRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewInterviewRepository(db *pgxpool.Pool) *InterviewRepository {
+	return &InterviewRepository{db: db}
+}
+
+const interviewColumns = `id, application_id, user_id, round_name, type, scheduled_at, timezone,
+    duration_minutes, interviewers, location, meeting_url, outcome, feedback, created_at, updated_at`
+
+func scanInterview(row pgx.Row) (*domain.Interview, error) {
+	var interview domain.Interview
+	err := row.Scan(
+		&interview.ID,
+		&interview.ApplicationID,
+		&interview.UserID,
+		&interview.RoundName,
+		&interview.Type,
+		&interview.ScheduledAt,
+		&interview.Timezone,
+		&interview.DurationMinutes,
+		&interview.Interviewers,
+		&interview.Location,
+		&interview.MeetingURL,
+		&interview.Outcome,
+		&interview.Feedback,
+		&interview.CreatedAt,
+		&interview.UpdatedAt,
+	)
+	return &interview, err
+}
+
+func (r *InterviewRepository) Create(ctx context.Context, interview *domain.Interview) error {
+	query := `INSERT INTO interviews (` + interviewColumns + `)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+	_, err := r.db.Exec(ctx, query,
+		interview.ID,
+		interview.ApplicationID,
+		interview.UserID,
+		interview.RoundName,
+		interview.Type,
+		interview.ScheduledAt,
+		interview.Timezone,
+		interview.DurationMinutes,
+		interview.Interviewers,
+		interview.Location,
+		interview.MeetingURL,
+		interview.Outcome,
+		interview.Feedback,
+		interview.CreatedAt,
+		interview.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create interview: %w", err)
+	}
+	return nil
+}
+
+func (r *InterviewRepository) GetByID(ctx context.Context, id string) (*domain.Interview, error) {
+	query := `SELECT ` + interviewColumns + ` FROM interviews WHERE id = $1`
+	interview, err := scanInterview(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if isNoRows(err) {
+			return nil, fmt.Errorf("interview %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get interview: %w", err)
+	}
+	return interview, nil
+}
+
+func (r *InterviewRepository) ListByApplicationID(ctx context.Context, applicationID string) ([]*domain.Interview, error) {
+	query := `SELECT ` + interviewColumns + ` FROM interviews WHERE application_id = $1 ORDER BY scheduled_at ASC`
+	return r.list(ctx, query, applicationID)
+}
+
+func (r *InterviewRepository) ListByUserID(ctx context.Context, userID string, since time.Time) ([]*domain.Interview, error) {
+	query := `SELECT ` + interviewColumns + ` FROM interviews WHERE user_id = $1 AND scheduled_at >= $2 ORDER BY scheduled_at ASC`
+	return r.list(ctx, query, userID, since)
+}
+
+func (r *InterviewRepository) list(ctx context.Context, query string, args ...any) ([]*domain.Interview, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query interviews: %w", err)
+	}
+	defer rows.Close()
+
+	interviews := []*domain.Interview{}
+	for rows.Next() {
+		interview, err := scanInterview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan interview row: %w", err)
+		}
+		interviews = append(interviews, interview)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating interview rows: %w", err)
+	}
+	return interviews, nil
+}
+
+func (r *InterviewRepository) Update(ctx context.Context, interview *domain.Interview) error {
+	query := `UPDATE interviews
+              SET round_name=$1, type=$2, scheduled_at=$3, timezone=$4, duration_minutes=$5, interviewers=$6,
+                  location=$7, meeting_url=$8, outcome=$9, feedback=$10, updated_at=$11
+              WHERE id=$12`
+	tag, err := r.db.Exec(ctx, query,
+		interview.RoundName,
+		interview.Type,
+		interview.ScheduledAt,
+		interview.Timezone,
+		interview.DurationMinutes,
+		interview.Interviewers,
+		interview.Location,
+		interview.MeetingURL,
+		interview.Outcome,
+		interview.Feedback,
+		interview.UpdatedAt,
+		interview.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update interview: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("interview %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *InterviewRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM interviews WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete interview: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("interview %w", domain.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"joblog/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LoginAttemptRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewLoginAttemptRepository(db *pgxpool.Pool) *LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+func (r *LoginAttemptRepository) Record(ctx context.Context, attempt *domain.LoginAttempt) error {
+	query := `INSERT INTO login_attempts (id, username, user_id, ip, user_agent, reason, created_at)
+              VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7)`
+	_, err := r.db.Exec(ctx, query, attempt.ID, attempt.Username, attempt.UserID, attempt.IP, attempt.UserAgent,
+		attempt.Reason, attempt.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record login attempt: %w", err)
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) GetLockout(ctx context.Context, username string) (*domain.LoginLockout, error) {
+	var lockout domain.LoginLockout
+	query := `SELECT username, failures, last_failure_at, locked_until FROM login_lockouts WHERE username = $1`
+	err := r.db.QueryRow(ctx, query, username).Scan(&lockout.Username, &lockout.Failures, &lockout.LastFailureAt, &lockout.LockedUntil)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get login lockout: %w", err)
+	}
+	return &lockout, nil
+}
+
+func (r *LoginAttemptRepository) AddFailure(ctx context.Context, username string, at, resetBefore time.Time) (int, error) {
+	query := `INSERT INTO login_lockouts (username, failures, last_failure_at) VALUES ($1, 1, $2)
+              ON CONFLICT (username) DO UPDATE SET
+                  failures = CASE WHEN login_lockouts.last_failure_at < $3 THEN 1 ELSE login_lockouts.failures + 1 END,
+                  last_failure_at = EXCLUDED.last_failure_at
+              RETURNING failures`
+	var failures int
+	if err := r.db.QueryRow(ctx, query, username, at, resetBefore).Scan(&failures); err != nil {
+		return 0, fmt.Errorf("failed to count login failure: %w", err)
+	}
+	return failures, nil
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, username string, until time.Time) error {
+	if _, err := r.db.Exec(ctx, `UPDATE login_lockouts SET locked_until = $1 WHERE username = $2`, until, username); err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) ClearFailures(ctx context.Context, username string) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM login_lockouts WHERE username = $1`, username); err != nil {
+		return fmt.Errorf("failed to clear login failures: %w", err)
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) Prune(ctx context.Context, attemptsBefore, failuresBefore time.Time) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM login_attempts WHERE created_at < $1`, attemptsBefore); err != nil {
+		return fmt.Errorf("failed to prune login attempts: %w", err)
+	}
+	query := `DELETE FROM login_lockouts WHERE last_failure_at < $1 AND (locked_until IS NULL OR locked_until < NOW())`
+	if _, err := r.db.Exec(ctx, query, failuresBefore); err != nil {
+		return fmt.Errorf("failed to prune login lockouts: %w", err)
+	}
+	return nil
+}
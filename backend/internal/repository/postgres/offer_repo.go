@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"joblog/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OfferRepository implements the domain.OfferRepository interface using PostgreSQL.
+// Equity and the negotiation history are stored as JSONB.
+type OfferRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOfferRepository(db *pgxpool.Pool) *OfferRepository {
+	return &OfferRepository{db: db}
+}
+
+const offerColumns = `id, application_id, user_id, currency, base_salary, pay_period, bonus, signing_bonus,
+    equity, benefits, start_date, decision_deadline, decision, negotiations, notes, created_at, updated_at`
+
+func scanOffer(row pgx.Row) (*domain.Offer, error) {
+	var offer domain.Offer
+	var equity, negotiations []byte
+	var startDate *time.Time
+	err := row.Scan(
+		&offer.ID,
+		&offer.ApplicationID,
+		&offer.UserID,
+		&offer.Currency,
+		&offer.BaseSalary,
+		&offer.PayPeriod,
+		&offer.Bonus,
+		&offer.SigningBonus,
+		&equity,
+		&offer.Benefits,
+		&startDate,
+		&offer.DecisionDeadline,
+		&offer.Decision,
+		&negotiations,
+		&offer.Notes,
+		&offer.CreatedAt,
+		&offer.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if equity != nil {
+		if err := json.Unmarshal(equity, &offer.Equity); err != nil {
+			return nil, fmt.Errorf("failed to decode equity: %w", err)
+		}
+	}
+	if err := json.Unmarshal(negotiations, &offer.Negotiations); err != nil {
+		return nil, fmt.Errorf("failed to decode negotiations: %w", err)
+	}
+	if startDate != nil {
+		offer.StartDate = startDate.Format("2006-01-02")
+	}
+	return &offer, nil
+}
+
+// offerDocuments encodes the JSONB columns of an offer.
+func offerDocuments(offer *domain.Offer) (equity, negotiations []byte, err error) {
+	if offer.Equity != nil {
+		if equity, err = json.Marshal(offer.Equity); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode equity: %w", err)
+		}
+	}
+	if negotiations, err = json.Marshal(offer.Negotiations); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode negotiations: %w", err)
+	}
+	return equity, negotiations, nil
+}
+
+func (r *OfferRepository) Create(ctx context.Context, offer *domain.Offer) error {
+	equity, negotiations, err := offerDocuments(offer)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO offers (` + offerColumns + `)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+	_, err = r.db.Exec(ctx, query,
+		offer.ID,
+		offer.ApplicationID,
+		offer.UserID,
+		offer.Currency,
+		offer.BaseSalary,
+		offer.PayPeriod,
+		offer.Bonus,
+		offer.SigningBonus,
+		equity,
+		offer.Benefits,
+		nullIfEmpty(offer.StartDate),
+		offer.DecisionDeadline,
+		offer.Decision,
+		negotiations,
+		offer.Notes,
+		offer.CreatedAt,
+		offer.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("%w: the application already has an offer", domain.ErrConflict)
+		}
+		return fmt.Errorf("failed to create offer: %w", err)
+	}
+	return nil
+}
+
+func (r *OfferRepository) GetByID(ctx context.Context, id string) (*domain.Offer, error) {
+	query := `SELECT ` + offerColumns + ` FROM offers WHERE id = $1`
+	return r.get(ctx, query, id)
+}
+
+func (r *OfferRepository) GetByApplicationID(ctx context.Context, applicationID string) (*domain.Offer, error) {
+	query := `SELECT ` + offerColumns + ` FROM offers WHERE application_id = $1`
+	return r.get(ctx, query, applicationID)
+}
+
+func (r *OfferRepository) get(ctx context.Context, query string, args ...any) (*domain.Offer, error) {
+	offer, err := scanOffer(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if isNoRows(err) {
+			return nil, fmt.Errorf("offer %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get offer: %w", err)
+	}
+	return offer, nil
+}
+
+func (r *OfferRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Offer, error) {
+	query := `SELECT ` + offerColumns + ` FROM offers WHERE user_id = $1 ORDER BY created_at DESC`
+	return r.list(ctx, query, userID)
+}
+
+func (r *OfferRepository) ListDeadlines(ctx context.Context, userID string, from, to time.Time) ([]*domain.Offer, error) {
+	query := `SELECT ` + offerColumns + ` FROM offers
+              WHERE user_id = $1 AND decision = $2 AND decision_deadline BETWEEN $3 AND $4
+              ORDER BY decision_deadline ASC`
+	return r.list(ctx, query, userID, domain.DecisionPending, from, to)
+}
+
+func (r *OfferRepository) list(ctx context.Context, query string, args ...any) ([]*domain.Offer, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query offers: %w", err)
+	}
+	defer rows.Close()
+
+	offers := []*domain.Offer{}
+	for rows.Next() {
+		offer, err := scanOffer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan offer row: %w", err)
+		}
+		offers = append(offers, offer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating offer rows: %w", err)
+	}
+	return offers, nil
+}
+
+func (r *OfferRepository) Update(ctx context.Context, offer *domain.Offer) error {
+	equity, negotiations, err := offerDocuments(offer)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE offers
+              SET currency=$1, base_salary=$2, pay_period=$3, bonus=$4, signing_bonus=$5, equity=$6, benefits=$7,
+                  start_date=$8, decision_deadline=$9, decision=$10, negotiations=$11, notes=$12, updated_at=$13
+              WHERE id=$14`
+	tag, err := r.db.Exec(ctx, query,
+		offer.Currency,
+		offer.BaseSalary,
+		offer.PayPeriod,
+		offer.Bonus,
+		offer.SigningBonus,
+		equity,
+		offer.Benefits,
+		nullIfEmpty(offer.StartDate),
+		offer.DecisionDeadline,
+		offer.Decision,
+		negotiations,
+		offer.Notes,
+		offer.UpdatedAt,
+		offer.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update offer: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("offer %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *OfferRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM offers WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete offer: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("offer %w", domain.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RateLimitRepository keeps rate limit counters in postgres, so every instance of the
+// server counts against the same limits.
+type RateLimitRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRateLimitRepository(db *pgxpool.Pool) *RateLimitRepository {
+	return &RateLimitRepository{db: db}
+}
+
+func (r *RateLimitRepository) Hit(ctx context.Context, key string, windowStart, previousStart time.Time) (int, int, error) {
+	query := `WITH hit AS (
+                  INSERT INTO rate_limit_windows (key, window_start, hits) VALUES ($1, $2, 1)
+                  ON CONFLICT (key, window_start) DO UPDATE SET hits = rate_limit_windows.hits + 1
+                  RETURNING hits
+              )
+              SELECT (SELECT hits FROM hit),
+                     COALESCE((SELECT hits FROM rate_limit_windows WHERE key = $1 AND window_start = $3), 0)`
+	var current, previous int
+	if err := r.db.QueryRow(ctx, query, key, windowStart, previousStart).Scan(&current, &previous); err != nil {
+		return 0, 0, fmt.Errorf("failed to count rate limit hit: %w", err)
+	}
+	return current, previous, nil
+}
+
+func (r *RateLimitRepository) DeleteKey(ctx context.Context, key string) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM rate_limit_windows WHERE key = $1`, key); err != nil {
+		return fmt.Errorf("failed to delete rate limit windows: %w", err)
+	}
+	return nil
+}
+
+func (r *RateLimitRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM rate_limit_windows WHERE window_start < $1`, before); err != nil {
+		return fmt.Errorf("failed to delete rate limit windows: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"joblog/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReminderRepository implements the domain.ReminderRepository interface using PostgreSQL.
+type ReminderRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReminderRepository(db *pgxpool.Pool) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+const reminderColumns = `id, user_id, application_id, due_at, message, done,
+    COALESCE(rule_id::text, ''), COALESCE(rule_anchor, ''), notified_at, delivery_attempts, next_attempt_at, created_at, updated_at`
+
+func scanReminder(row pgx.Row) (*domain.Reminder, error) {
+	var reminder domain.Reminder
+	err := row.Scan(
+		&reminder.ID,
+		&reminder.UserID,
+		&reminder.ApplicationID,
+		&reminder.DueAt,
+		&reminder.Message,
+		&reminder.Done,
+		&reminder.RuleID,
+		&reminder.RuleAnchor,
+		&reminder.NotifiedAt,
+		&reminder.DeliveryAttempts,
+		&reminder.NextAttemptAt,
+		&reminder.CreatedAt,
+		&reminder.UpdatedAt,
+	)
+	return &reminder, err
+}
+
+func (r *ReminderRepository) Create(ctx context.Context, reminder *domain.Reminder) error {
+	query := `INSERT INTO reminders (id, user_id, application_id, due_at, message, done, rule_id, rule_anchor, notified_at, created_at, updated_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err := r.db.Exec(ctx, query,
+		reminder.ID,
+		reminder.UserID,
+		reminder.ApplicationID,
+		reminder.DueAt,
+		reminder.Message,
+		reminder.Done,
+		nullIfEmpty(reminder.RuleID),
+		nullIfEmpty(reminder.RuleAnchor),
+		reminder.NotifiedAt,
+		reminder.CreatedAt,
+		reminder.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create reminder: %w", err)
+	}
+	return nil
+}
+
+func (r *ReminderRepository) GetByID(ctx context.Context, id string) (*domain.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM reminders WHERE id = $1`
+	reminder, err := scanReminder(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if isNoRows(err) {
+			return nil, fmt.Errorf("reminder %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get reminder: %w", err)
+	}
+	return reminder, nil
+}
+
+func (r *ReminderRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM reminders WHERE user_id = $1 ORDER BY due_at ASC`
+	return r.list(ctx, query, userID)
+}
+
+func (r *ReminderRepository) ListByApplicationID(ctx context.Context, applicationID string) ([]*domain.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM reminders WHERE application_id = $1 ORDER BY due_at ASC`
+	return r.list(ctx, query, applicationID)
+}
+
+func (r *ReminderRepository) ListDue(ctx context.Context, userID string, now time.Time) ([]*domain.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM reminders
+              WHERE user_id = $1 AND NOT done AND due_at <= $2
+              ORDER BY due_at ASC`
+	return r.list(ctx, query, userID, now)
+}
+
+func (r *ReminderRepository) list(ctx context.Context, query string, args ...any) ([]*domain.Reminder, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reminders: %w", err)
+	}
+	defer rows.Close()
+
+	reminders := []*domain.Reminder{}
+	for rows.Next() {
+		reminder, err := scanReminder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reminder row: %w", err)
+		}
+		reminders = append(reminders, reminder)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reminder rows: %w", err)
+	}
+	return reminders, nil
+}
+
+func (r *ReminderRepository) Update(ctx context.Context, reminder *domain.Reminder) error {
+	query := `UPDATE reminders SET due_at=$1, message=$2, done=$3, notified_at=$4, delivery_attempts=$5, next_attempt_at=$6, updated_at=$7
+              WHERE id=$8`
+	tag, err := r.db.Exec(ctx, query, reminder.DueAt, reminder.Message, reminder.Done, reminder.NotifiedAt,
+		reminder.DeliveryAttempts, reminder.NextAttemptAt, reminder.UpdatedAt, reminder.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update reminder: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("reminder %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *ReminderRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM reminders WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete reminder: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("reminder %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *ReminderRepository) ExistsForRule(ctx context.Context, applicationID, ruleID, anchor string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM reminders WHERE application_id = $1 AND rule_id = $2 AND rule_anchor = $3)`
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, applicationID, ruleID, anchor).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check rule reminders: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *ReminderRepository) ClaimUndelivered(ctx context.Context, now time.Time, limit, maxAttempts int) ([]*domain.Reminder, error) {
+	// SKIP LOCKED lets several server instances run the scheduler without handing out the same rows
+	query := `UPDATE reminders SET notified_at = $1
+              WHERE id IN (
+                  SELECT id FROM reminders
+                  WHERE NOT done AND notified_at IS NULL AND due_at <= $1
+                        AND delivery_attempts < $3 AND (next_attempt_at IS NULL OR next_attempt_at <= $1)
+                  ORDER BY due_at ASC
+                  LIMIT $2
+                  FOR UPDATE SKIP LOCKED
+              )
+              RETURNING ` + reminderColumns
+	return r.list(ctx, query, now, limit, maxAttempts)
+}
+
+func (r *ReminderRepository) ReleaseClaim(ctx context.Context, id string, retryAt time.Time) error {
+	query := `UPDATE reminders SET notified_at = NULL, delivery_attempts = delivery_attempts + 1, next_attempt_at = $2
+              WHERE id = $1`
+	if _, err := r.db.Exec(ctx, query, id, retryAt); err != nil {
+		return fmt.Errorf("failed to release reminder: %w", err)
+	}
+	return nil
+}
+
+// FollowUpRuleRepository implements the domain.FollowUpRuleRepository interface using PostgreSQL.
+type FollowUpRuleRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewFollowUpRuleRepository(db *pgxpool.Pool) *FollowUpRuleRepository {
+	return &FollowUpRuleRepository{db: db}
+}
+
+const followUpRuleColumns = `id, user_id, status, after_days, message, enabled`
+
+func scanFollowUpRule(row pgx.Row) (*domain.FollowUpRule, error) {
+	var rule domain.FollowUpRule
+	err := row.Scan(&rule.ID, &rule.UserID, &rule.Status, &rule.AfterDays, &rule.Message, &rule.Enabled)
+	return &rule, err
+}
+
+func (r *FollowUpRuleRepository) Create(ctx context.Context, rule *domain.FollowUpRule) error {
+	query := `INSERT INTO follow_up_rules (` + followUpRuleColumns + `) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.db.Exec(ctx, query, rule.ID, rule.UserID, rule.Status, rule.AfterDays, rule.Message, rule.Enabled)
+	if err != nil {
+		return fmt.Errorf("failed to create follow-up rule: %w", err)
+	}
+	return nil
+}
+
+func (r *FollowUpRuleRepository) GetByID(ctx context.Context, id string) (*domain.FollowUpRule, error) {
+	query := `SELECT ` + followUpRuleColumns + ` FROM follow_up_rules WHERE id = $1`
+	rule, err := scanFollowUpRule(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if isNoRows(err) {
+			return nil, fmt.Errorf("follow-up rule %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get follow-up rule: %w", err)
+	}
+	return rule, nil
+}
+
+func (r *FollowUpRuleRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.FollowUpRule, error) {
+	query := `SELECT ` + followUpRuleColumns + ` FROM follow_up_rules WHERE user_id = $1 ORDER BY created_at ASC`
+	return r.list(ctx, query, userID)
+}
+
+func (r *FollowUpRuleRepository) ListEnabled(ctx context.Context) ([]*domain.FollowUpRule, error) {
+	query := `SELECT ` + followUpRuleColumns + ` FROM follow_up_rules WHERE enabled ORDER BY user_id, created_at ASC`
+	return r.list(ctx, query)
+}
+
+func (r *FollowUpRuleRepository) list(ctx context.Context, query string, args ...any) ([]*domain.FollowUpRule, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query follow-up rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []*domain.FollowUpRule{}
+	for rows.Next() {
+		rule, err := scanFollowUpRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan follow-up rule row: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating follow-up rule rows: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *FollowUpRuleRepository) Update(ctx context.Context, rule *domain.FollowUpRule) error {
+	query := `UPDATE follow_up_rules SET status=$1, after_days=$2, message=$3, enabled=$4 WHERE id=$5`
+	tag, err := r.db.Exec(ctx, query, rule.Status, rule.AfterDays, rule.Message, rule.Enabled, rule.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update follow-up rule: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("follow-up rule %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *FollowUpRuleRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM follow_up_rules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete follow-up rule: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("follow-up rule %w", domain.ErrNotFound)
+	}
+	return nil
+}
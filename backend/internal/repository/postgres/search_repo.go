@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"joblog/internal/core/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SearchRepository implements the domain.SearchRepository interface using the generated
+// search_vector columns on applications, notes and history_events.
+type SearchRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSearchRepository(db *pgxpool.Pool) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// searchQuery collects every matching row of the three tables, weighting a match on the
+// company or role above one in a note, and a note above a history entry. Applications are
+// ranked by the sum of their matches; snippets are only highlighted for the ones returned.
+const searchQuery = `
+WITH q AS (
+    SELECT websearch_to_tsquery('english', $2) AS query
+),
+matches AS (
+    SELECT a.id AS application_id, 'application' AS source, NULL::uuid AS note_id,
+           a.company || ' - ' || a.role AS body, 2 * ts_rank(a.search_vector, q.query) AS rank
+    FROM applications a, q
+    WHERE a.user_id = $1 AND a.status != 'Archived' AND a.search_vector @@ q.query
+    UNION ALL
+    SELECT n.application_id, 'note', n.id, n.content, ts_rank(n.search_vector, q.query)
+    FROM notes n JOIN applications a ON a.id = n.application_id, q
+    WHERE a.user_id = $1 AND a.status != 'Archived' AND n.search_vector @@ q.query
+    UNION ALL
+    SELECT h.application_id, 'history', NULL, h.event || COALESCE(': ' || h.reason, ''), 0.5 * ts_rank(h.search_vector, q.query)
+    FROM history_events h JOIN applications a ON a.id = h.application_id, q
+    WHERE a.user_id = $1 AND a.status != 'Archived' AND h.search_vector @@ q.query
+),
+ranked AS (
+    SELECT application_id, SUM(rank)::float8 AS rank
+    FROM matches
+    GROUP BY application_id
+    ORDER BY rank DESC, application_id
+    LIMIT $3
+)
+SELECT r.application_id, a.company, a.role, a.status, r.rank, m.source, m.note_id,
+       ts_headline('english', translate(m.body, chr(2) || chr(3), ''), q.query, 'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MinWords=5, MaxWords=20')
+FROM ranked r
+JOIN applications a ON a.id = r.application_id
+JOIN matches m ON m.application_id = r.application_id, q
+ORDER BY r.rank DESC, r.application_id, m.rank DESC`
+
+// ts_headline marks matches with control characters rather than <mark>, so the snippet can
+// be HTML-escaped before the real markup goes in. The query strips them from the text first.
+var headlineMarkup = strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>")
+
+func (r *SearchRepository) Search(ctx context.Context, userID, query string, limit int) ([]*domain.SearchResult, error) {
+	rows, err := r.db.Query(ctx, searchQuery, userID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search applications: %w", err)
+	}
+	defer rows.Close()
+
+	results := []*domain.SearchResult{}
+	var current *domain.SearchResult
+	for rows.Next() {
+		var (
+			result domain.SearchResult
+			match  domain.SearchMatch
+			noteID *string
+		)
+		if err := rows.Scan(&result.ApplicationID, &result.Company, &result.Role, &result.Status, &result.Rank,
+			&match.Source, &noteID, &match.Snippet); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		if noteID != nil {
+			match.NoteID = *noteID
+		}
+		match.Snippet = headlineMarkup.Replace(html.EscapeString(match.Snippet))
+
+		if current == nil || current.ApplicationID != result.ApplicationID {
+			current = &result
+			results = append(results, current)
+		}
+		current.Matches = append(current.Matches, match)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search results: %w", err)
+	}
+	return results, nil
+}
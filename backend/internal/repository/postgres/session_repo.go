@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"joblog/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SessionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSessionRepository(db *pgxpool.Pool) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+const sessionColumns = `id, user_id, token_hash, previous_token_hashes, rotated_at, user_agent, ip, created_at, last_used_at, expires_at, revoked_at`
+
+func scanSession(row pgx.Row) (*domain.Session, error) {
+	var session domain.Session
+	err := row.Scan(&session.ID, &session.UserID, &session.TokenHash, &session.PreviousTokenHashes, &session.RotatedAt, &session.UserAgent, &session.IP,
+		&session.CreatedAt, &session.LastUsedAt, &session.ExpiresAt, &session.RevokedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	query := `INSERT INTO sessions (id, user_id, token_hash, user_agent, ip, created_at, last_used_at, expires_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.db.Exec(ctx, query, session.ID, session.UserID, session.TokenHash, session.UserAgent, session.IP,
+		session.CreatedAt, session.LastUsedAt, session.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (*domain.Session, error) {
+	session, err := scanSession(r.db.QueryRow(ctx, `SELECT `+sessionColumns+` FROM sessions WHERE id = $1`, id))
+	if err != nil {
+		if isNoRows(err) {
+			return nil, fmt.Errorf("session %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return session, nil
+}
+
+func (r *SessionRepository) ListActiveByUserID(ctx context.Context, userID string) ([]*domain.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions
+              WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+              ORDER BY last_used_at DESC`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*domain.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session row: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating session rows: %w", err)
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepository) Rotate(ctx context.Context, session *domain.Session, previousHash string) (bool, error) {
+	query := `UPDATE sessions SET token_hash = $1, previous_token_hashes = $2, rotated_at = $3,
+                  user_agent = $4, ip = $5, last_used_at = $6, expires_at = $7
+              WHERE id = $8 AND token_hash = $9 AND revoked_at IS NULL AND expires_at > NOW()`
+	tag, err := r.db.Exec(ctx, query, session.TokenHash, session.PreviousTokenHashes, session.RotatedAt,
+		session.UserAgent, session.IP, session.LastUsedAt, session.ExpiresAt, session.ID, previousHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate session: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, id string) error {
+	query := `UPDATE sessions SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`
+	if _, err := r.db.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+func (r *SessionRepository) RevokeAllByUserID(ctx context.Context, userID, exceptID string) error {
+	query := `UPDATE sessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL AND id::text != $2`
+	if _, err := r.db.Exec(ctx, query, userID, exceptID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
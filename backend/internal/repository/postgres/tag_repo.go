@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"joblog/internal/core/domain"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TagRepository implements the domain.TagRepository interface using PostgreSQL.
+// Applications reference tags through application_tags, so a rename shows up on every
+// tagged application at once and a delete cascades to all of them.
+type TagRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTagRepository(db *pgxpool.Pool) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// tagConflict turns a unique violation on (user_id, lower(name)) into domain.ErrConflict.
+func tagConflict(err error, name string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return fmt.Errorf("%w: a tag named %q already exists", domain.ErrConflict, name)
+	}
+	return nil
+}
+
+func (r *TagRepository) Create(ctx context.Context, tag *domain.Tag) error {
+	return insertTag(ctx, r.db, tag)
+}
+
+// insertTag is shared with ApplicationRepository.ApplyBatch, which creates tags in its transaction.
+func insertTag(ctx context.Context, db dbtx, tag *domain.Tag) error {
+	query := `INSERT INTO tags (id, user_id, name, color) VALUES ($1, $2, $3, $4)`
+	if _, err := db.Exec(ctx, query, tag.ID, tag.UserID, tag.Name, tag.Color); err != nil {
+		if conflict := tagConflict(err, tag.Name); conflict != nil {
+			return conflict
+		}
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+	return nil
+}
+
+func (r *TagRepository) GetByID(ctx context.Context, id string) (*domain.Tag, error) {
+	var tag domain.Tag
+	query := `SELECT id, user_id, name, color FROM tags WHERE id = $1`
+	err := r.db.QueryRow(ctx, query, id).Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.Color)
+	if err != nil {
+		if isNoRows(err) {
+			return nil, fmt.Errorf("tag %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+	return &tag, nil
+}
+
+func (r *TagRepository) ListSummaries(ctx context.Context, userID string) ([]*domain.TagSummary, error) {
+	query := `SELECT t.id, t.user_id, t.name, t.color, COUNT(at.application_id)::int
+              FROM tags t
+              LEFT JOIN application_tags at ON at.tag_id = t.id
+              WHERE t.user_id = $1
+              GROUP BY t.id
+              ORDER BY lower(t.name)`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := []*domain.TagSummary{}
+	for rows.Next() {
+		var summary domain.TagSummary
+		if err := rows.Scan(&summary.ID, &summary.UserID, &summary.Name, &summary.Color, &summary.ApplicationCount); err != nil {
+			return nil, fmt.Errorf("failed to scan tag row: %w", err)
+		}
+		summaries = append(summaries, &summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tag rows: %w", err)
+	}
+	return summaries, nil
+}
+
+func (r *TagRepository) Update(ctx context.Context, tag *domain.Tag) error {
+	query := `UPDATE tags SET name=$1, color=$2, updated_at=NOW() WHERE id=$3`
+	result, err := r.db.Exec(ctx, query, tag.Name, tag.Color, tag.ID)
+	if err != nil {
+		if conflict := tagConflict(err, tag.Name); conflict != nil {
+			return conflict
+		}
+		return fmt.Errorf("failed to update tag: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("tag %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *TagRepository) Delete(ctx context.Context, id string) error {
+	// application_tags rows go with it through ON DELETE CASCADE, in the same statement
+	result, err := r.db.Exec(ctx, `DELETE FROM tags WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("tag %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *TagRepository) Merge(ctx context.Context, sourceID, targetID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Applications that already carry both tags keep a single link to the target
+	query := `INSERT INTO application_tags (application_id, tag_id)
+              SELECT application_id, $2 FROM application_tags WHERE tag_id = $1
+              ON CONFLICT (application_id, tag_id) DO NOTHING`
+	if _, err := tx.Exec(ctx, query, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to move tagged applications: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM tags WHERE id = $1`, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to delete merged tag: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("tag %w", domain.ErrNotFound)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *TagRepository) Assign(ctx context.Context, applicationID, tagID string) error {
+	query := `INSERT INTO application_tags (application_id, tag_id) VALUES ($1, $2)
+              ON CONFLICT (application_id, tag_id) DO NOTHING`
+	if _, err := r.db.Exec(ctx, query, applicationID, tagID); err != nil {
+		return fmt.Errorf("failed to assign tag: %w", err)
+	}
+	return nil
+}
+
+func (r *TagRepository) Unassign(ctx context.Context, applicationID, tagID string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM application_tags WHERE application_id = $1 AND tag_id = $2`, applicationID, tagID)
+	if err != nil {
+		return fmt.Errorf("failed to unassign tag: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("tag is not assigned to this application")
+	}
+	return nil
+}
@@ -1,4 +1,3 @@
-
 package postgres
 
 import (
@@ -9,6 +8,7 @@ import (
 	"joblog/internal/core/domain"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -41,11 +41,107 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	return r.getUserByField(ctx, "email", email)
 }
 
+// Delete purges the user and all of their data in a single transaction.
+// Most rows would go through ON DELETE CASCADE anyway; deleting them explicitly
+// keeps the purge complete even for rows that are only linked by foreign keys added later.
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	statements := []struct {
+		query string
+		what  string
+	}{
+		{`DELETE FROM comments WHERE author_id = $1`, "comments"},
+		{`DELETE FROM blog_posts WHERE author_id = $1`, "blog posts"},
+		{`DELETE FROM applications WHERE user_id = $1`, "applications"}, // Cascades to notes and history
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt.query, id); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", stmt.what, err)
+		}
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE users SET username = $1, email = $2,
+                  email_verified_at = CASE WHEN $3 THEN COALESCE(email_verified_at, NOW()) END,
+                  pending_email = NULLIF($4, '')
+              WHERE id = $5`
+	tag, err := tx.Exec(ctx, query, user.Username, user.Email, user.EmailVerified, user.PendingEmail, user.ID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			if pgErr.ConstraintName == "users_email_key" {
+				return fmt.Errorf("%w: user with email %s already exists", domain.ErrConflict, user.Email)
+			}
+			return fmt.Errorf("%w: user with username %s already exists", domain.ErrConflict, user.Username)
+		}
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	// Blog posts and comments keep a copy of the author's name for display
+	if _, err := tx.Exec(ctx, `UPDATE blog_posts SET author_name = $1 WHERE author_id = $2`, user.Username, user.ID); err != nil {
+		return fmt.Errorf("failed to rename blog post author: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `UPDATE comments SET author_name = $1 WHERE author_id = $2`, user.Username, user.ID); err != nil {
+		return fmt.Errorf("failed to rename comment author: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id, passwordHash string) error {
+	tag, err := r.db.Exec(ctx, `UPDATE users SET password_hash = $1 WHERE id = $2`, passwordHash, id)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+	return nil
+}
+
+func (r *UserRepository) MarkEmailVerified(ctx context.Context, id string) error {
+	query := `UPDATE users SET email_verified_at = COALESCE(email_verified_at, NOW()) WHERE id = $1`
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark email verified: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+	return nil
+}
+
 // Helper function to reduce repetition
 func (r *UserRepository) getUserByField(ctx context.Context, field string, value any) (*domain.User, error) {
 	var user domain.User
-	query := fmt.Sprintf(`SELECT id, username, email, password_hash FROM users WHERE %s = $1`, field)
-	err := r.db.QueryRow(ctx, query, value).Scan(&user.ID, &user.Username, &user.Email, &user.PasswordHash)
+	query := fmt.Sprintf(`SELECT id, username, email, email_verified_at IS NOT NULL, COALESCE(pending_email, ''), password_hash
+                          FROM users WHERE %s = $1`, field)
+	err := r.db.QueryRow(ctx, query, value).Scan(&user.ID, &user.Username, &user.Email, &user.EmailVerified, &user.PendingEmail, &user.PasswordHash)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("user not found")
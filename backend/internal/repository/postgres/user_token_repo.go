@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"joblog/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UserTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewUserTokenRepository(db *pgxpool.Pool) *UserTokenRepository {
+	return &UserTokenRepository{db: db}
+}
+
+func (r *UserTokenRepository) Create(ctx context.Context, token *domain.UserToken) error {
+	query := `INSERT INTO user_tokens (id, user_id, purpose, token_hash, created_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.db.Exec(ctx, query, token.ID, token.UserID, token.Purpose, token.TokenHash, token.CreatedAt, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create user token: %w", err)
+	}
+	return nil
+}
+
+func (r *UserTokenRepository) Consume(ctx context.Context, purpose domain.UserTokenPurpose, tokenHash string) (*domain.UserToken, error) {
+	query := `UPDATE user_tokens SET used_at = NOW()
+              WHERE purpose = $1 AND token_hash = $2 AND used_at IS NULL AND expires_at > NOW()
+              RETURNING id, user_id, purpose, token_hash, created_at, expires_at, used_at`
+	var token domain.UserToken
+	err := r.db.QueryRow(ctx, query, purpose, tokenHash).Scan(&token.ID, &token.UserID, &token.Purpose, &token.TokenHash,
+		&token.CreatedAt, &token.ExpiresAt, &token.UsedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("token not found")
+		}
+		return nil, fmt.Errorf("failed to consume user token: %w", err)
+	}
+	return &token, nil
+}
+
+func (r *UserTokenRepository) InvalidateByUserID(ctx context.Context, userID string, purpose domain.UserTokenPurpose) error {
+	query := `UPDATE user_tokens SET used_at = NOW() WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`
+	if _, err := r.db.Exec(ctx, query, userID, purpose); err != nil {
+		return fmt.Errorf("failed to invalidate user tokens: %w", err)
+	}
+	return nil
+}
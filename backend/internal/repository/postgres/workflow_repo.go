@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"joblog/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WorkflowRepository stores each user's workflow as a JSONB document.
+type WorkflowRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWorkflowRepository(db *pgxpool.Pool) *WorkflowRepository {
+	return &WorkflowRepository{db: db}
+}
+
+func (r *WorkflowRepository) GetByUserID(ctx context.Context, userID string) (*domain.Workflow, error) {
+	var definition []byte
+	err := r.db.QueryRow(ctx, `SELECT definition FROM user_workflows WHERE user_id = $1`, userID).Scan(&definition)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	var workflow domain.Workflow
+	if err := json.Unmarshal(definition, &workflow); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow: %w", err)
+	}
+	return &workflow, nil
+}
+
+func (r *WorkflowRepository) Save(ctx context.Context, userID string, workflow *domain.Workflow) error {
+	definition, err := json.Marshal(workflow)
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow: %w", err)
+	}
+
+	query := `INSERT INTO user_workflows (user_id, definition) VALUES ($1, $2)
+              ON CONFLICT (user_id) DO UPDATE SET definition = EXCLUDED.definition, updated_at = NOW()`
+	if _, err := r.db.Exec(ctx, query, userID, definition); err != nil {
+		return fmt.Errorf("failed to save workflow: %w", err)
+	}
+	return nil
+}
+
+func (r *WorkflowRepository) Delete(ctx context.Context, userID string) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM user_workflows WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete workflow: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"joblog/internal/core/service"
+)
+
+// LoginCleanupWorker periodically drops expired rate limit counters and lockouts, and
+// failed logins past their audit retention.
+type LoginCleanupWorker struct {
+	loginGuard *service.LoginGuard
+	interval   time.Duration
+}
+
+func NewLoginCleanupWorker(loginGuard *service.LoginGuard, interval time.Duration) *LoginCleanupWorker {
+	return &LoginCleanupWorker{loginGuard: loginGuard, interval: interval}
+}
+
+// Run cleans up immediately and then on every interval, until ctx is cancelled.
+func (w *LoginCleanupWorker) Run(ctx context.Context) {
+	log.Printf("[LoginCleanupWorker] Started, running every %s", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.loginGuard.Prune(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			log.Println("[LoginCleanupWorker.Prune] Error:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("[LoginCleanupWorker] Stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
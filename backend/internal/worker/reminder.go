@@ -0,0 +1,57 @@
+// Package worker holds background jobs that run alongside the HTTP server.
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"joblog/internal/core/service"
+)
+
+// ReminderWorker periodically turns follow-up rules into reminders and
+// delivers the reminders that have come due.
+type ReminderWorker struct {
+	reminders *service.ReminderService
+	interval  time.Duration
+}
+
+func NewReminderWorker(reminders *service.ReminderService, interval time.Duration) *ReminderWorker {
+	return &ReminderWorker{reminders: reminders, interval: interval}
+}
+
+// Run processes reminders immediately and then on every interval, until ctx is cancelled.
+func (w *ReminderWorker) Run(ctx context.Context) {
+	log.Printf("[ReminderWorker] Started, running every %s", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Println("[ReminderWorker] Stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *ReminderWorker) tick(ctx context.Context) {
+	now := time.Now()
+
+	created, err := w.reminders.EvaluateRules(ctx, now)
+	if err != nil && ctx.Err() == nil {
+		log.Println("[ReminderWorker.EvaluateRules] Error:", err)
+	}
+
+	delivered, err := w.reminders.DeliverDue(ctx, now)
+	if err != nil && ctx.Err() == nil {
+		log.Println("[ReminderWorker.DeliverDue] Error:", err)
+	}
+
+	if created > 0 || delivered > 0 {
+		log.Printf("[ReminderWorker] Created %d and delivered %d reminders", created, delivered)
+	}
+}
@@ -0,0 +1,18 @@
+-- Link blog posts and comments to the users who wrote them, so that deleting
+-- an account also removes everything it authored.
+ALTER TABLE blog_posts ADD COLUMN author_id UUID REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE comments ADD COLUMN author_id UUID REFERENCES users(id) ON DELETE CASCADE;
+
+-- Backfill from the author name, which has always been the username
+UPDATE blog_posts p SET author_id = u.id FROM users u WHERE p.author_name = u.username;
+UPDATE comments c SET author_id = u.id FROM users u WHERE c.author_name = u.username;
+
+CREATE INDEX ON blog_posts (author_id);
+CREATE INDEX ON comments (author_id);
+
+-- -- migrations/000003_add_blog_author_ids.down.sql
+
+-- DROP INDEX IF EXISTS comments_author_id_idx;
+-- DROP INDEX IF EXISTS blog_posts_author_id_idx;
+-- ALTER TABLE comments DROP COLUMN IF EXISTS author_id;
+-- ALTER TABLE blog_posts DROP COLUMN IF EXISTS author_id;
@@ -0,0 +1,53 @@
+-- Turn history events into structured records so funnel metrics and
+-- time-in-stage can be computed without parsing the message text.
+ALTER TABLE history_events
+    ADD COLUMN type VARCHAR(50),
+    ADD COLUMN from_status VARCHAR(50),
+    ADD COLUMN to_status VARCHAR(50),
+    ADD COLUMN fields TEXT[] NOT NULL DEFAULT '{}';
+
+-- Backfill from the message formats written by earlier versions of the API
+UPDATE history_events
+SET type = 'created', to_status = substring(event FROM '^Application created with status: (.*)$')
+WHERE event LIKE 'Application created with status: %';
+
+UPDATE history_events
+SET type = 'status_changed', to_status = substring(event FROM '^Status updated to: (.*)$')
+WHERE event LIKE 'Status updated to: %';
+
+UPDATE history_events
+SET type = 'restored', from_status = 'Archived', to_status = substring(event FROM '^Application restored to status: (.*)$')
+WHERE event LIKE 'Application restored to status: %';
+
+UPDATE history_events
+SET type = 'archived', to_status = 'Archived'
+WHERE event = 'Application archived';
+
+-- Nothing else was ever written by the API; treat any leftovers as edits
+UPDATE history_events SET type = 'field_edited' WHERE type IS NULL;
+
+-- The status an event moved away from is the last status recorded before it
+UPDATE history_events h
+SET from_status = (
+    SELECT p.to_status
+    FROM history_events p
+    WHERE p.application_id = h.application_id
+      AND p.created_at < h.created_at
+      AND p.to_status IS NOT NULL
+    ORDER BY p.created_at DESC
+    LIMIT 1
+)
+WHERE h.type IN ('status_changed', 'archived');
+
+ALTER TABLE history_events ALTER COLUMN type SET NOT NULL;
+
+CREATE INDEX ON history_events (type);
+
+-- -- migrations/000004_structure_history_events.down.sql
+
+-- DROP INDEX IF EXISTS history_events_type_idx;
+-- ALTER TABLE history_events
+--     DROP COLUMN IF EXISTS fields,
+--     DROP COLUMN IF EXISTS to_status,
+--     DROP COLUMN IF EXISTS from_status,
+--     DROP COLUMN IF EXISTS type;
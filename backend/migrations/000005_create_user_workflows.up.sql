@@ -0,0 +1,14 @@
+-- Per-user status workflows. Users without a row get the built-in default.
+CREATE TABLE user_workflows (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    definition JSONB NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Status changes that the workflow only allows with a justification
+ALTER TABLE history_events ADD COLUMN reason TEXT;
+
+-- -- migrations/000005_create_user_workflows.down.sql
+
+-- ALTER TABLE history_events DROP COLUMN IF EXISTS reason;
+-- DROP TABLE IF EXISTS user_workflows;
@@ -0,0 +1,24 @@
+CREATE TABLE interviews (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    round_name VARCHAR(255) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    duration_minutes INT NOT NULL DEFAULT 60,
+    interviewers TEXT[] NOT NULL DEFAULT '{}',
+    location TEXT NOT NULL DEFAULT '',
+    meeting_url TEXT NOT NULL DEFAULT '',
+    outcome VARCHAR(50) NOT NULL DEFAULT 'pending',
+    feedback TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON interviews (application_id);
+CREATE INDEX ON interviews (user_id, scheduled_at);
+
+-- -- migrations/000006_create_interviews.down.sql
+
+-- DROP TABLE IF EXISTS interviews;
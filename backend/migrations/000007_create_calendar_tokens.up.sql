@@ -0,0 +1,11 @@
+-- Secret tokens for the per-user iCalendar feed. Calendar clients cannot send
+-- an Authorization header, so the token is part of the feed URL. Only its hash is stored.
+CREATE TABLE calendar_tokens (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- -- migrations/000007_create_calendar_tokens.down.sql
+
+-- DROP TABLE IF EXISTS calendar_tokens;
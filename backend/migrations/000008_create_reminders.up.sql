@@ -0,0 +1,36 @@
+CREATE TABLE follow_up_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL,
+    after_days INT NOT NULL,
+    message TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE reminders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    due_at TIMESTAMPTZ NOT NULL,
+    message TEXT NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    rule_id UUID REFERENCES follow_up_rules(id) ON DELETE SET NULL,
+    rule_anchor VARCHAR(32),
+    notified_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON follow_up_rules (user_id);
+CREATE INDEX ON reminders (user_id, due_at);
+CREATE INDEX ON reminders (application_id);
+-- The scheduler looks for open reminders that have not been delivered yet
+CREATE INDEX ON reminders (due_at) WHERE NOT done AND notified_at IS NULL;
+-- A rule fires at most once per application per last-update date
+CREATE UNIQUE INDEX ON reminders (application_id, rule_id, rule_anchor) WHERE rule_id IS NOT NULL;
+
+-- -- migrations/000008_create_reminders.down.sql
+
+-- DROP TABLE IF EXISTS reminders;
+-- DROP TABLE IF EXISTS follow_up_rules;
@@ -0,0 +1,29 @@
+CREATE TABLE contacts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone VARCHAR(50) NOT NULL DEFAULT '',
+    linkedin_url TEXT NOT NULL DEFAULT '',
+    company VARCHAR(255) NOT NULL DEFAULT '',
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE application_contacts (
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    contact_id UUID NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    role VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (application_id, contact_id)
+);
+
+CREATE INDEX ON contacts (user_id, lower(name));
+CREATE INDEX ON application_contacts (contact_id);
+
+-- -- migrations/000009_create_contacts.down.sql
+
+-- DROP TABLE IF EXISTS application_contacts;
+-- DROP TABLE IF EXISTS contacts;
@@ -0,0 +1,49 @@
+CREATE TABLE companies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    normalized_name VARCHAR(255) NOT NULL,
+    website TEXT NOT NULL DEFAULT '',
+    industry VARCHAR(255) NOT NULL DEFAULT '',
+    size VARCHAR(50) NOT NULL DEFAULT '',
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, normalized_name)
+);
+
+ALTER TABLE applications ADD COLUMN company_id UUID REFERENCES companies(id);
+
+-- Same rules as domain.NormalizeCompanyName; only needed for the backfill below
+CREATE FUNCTION normalize_company_name(name TEXT) RETURNS TEXT AS $$
+    SELECT COALESCE(
+        NULLIF(
+            regexp_replace(
+                btrim(regexp_replace(regexp_replace(lower(name), '[.'']', '', 'g'), '[^[:alnum:]&]+', ' ', 'g')),
+                '( (inc|incorporated|llc|ltd|limited|corp|corporation|co|gmbh|plc|ag|sa|bv|nv|pty|srl|oy|ab))+$', ''),
+            ''),
+        lower(btrim(name)))
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- One company per user and normalized name, named after the earliest application's spelling
+INSERT INTO companies (user_id, name, normalized_name)
+SELECT DISTINCT ON (user_id, normalize_company_name(company))
+       user_id, btrim(company), normalize_company_name(company)
+FROM applications
+ORDER BY user_id, normalize_company_name(company), date ASC, id ASC;
+
+UPDATE applications a
+SET company_id = c.id
+FROM companies c
+WHERE c.user_id = a.user_id AND c.normalized_name = normalize_company_name(a.company);
+
+DROP FUNCTION normalize_company_name(TEXT);
+
+ALTER TABLE applications ALTER COLUMN company_id SET NOT NULL;
+CREATE INDEX ON applications (company_id);
+
+-- -- migrations/000010_create_companies.down.sql
+
+-- ALTER TABLE applications DROP COLUMN IF EXISTS company_id;
+-- DROP TABLE IF EXISTS companies;
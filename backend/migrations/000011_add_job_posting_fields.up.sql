@@ -0,0 +1,27 @@
+ALTER TABLE applications
+    ADD COLUMN job_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN location VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN work_mode VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN salary_min INTEGER CHECK (salary_min >= 0),
+    ADD COLUMN salary_max INTEGER CHECK (salary_max >= 0),
+    ADD COLUMN salary_currency VARCHAR(3) NOT NULL DEFAULT '',
+    ADD COLUMN employment_type VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN priority VARCHAR(10) NOT NULL DEFAULT '',
+    ADD COLUMN job_description TEXT NOT NULL DEFAULT '',
+    ADD CONSTRAINT applications_salary_range CHECK (salary_min IS NULL OR salary_max IS NULL OR salary_min <= salary_max);
+
+-- -- migrations/000011_add_job_posting_fields.down.sql
+
+-- ALTER TABLE applications
+--     DROP CONSTRAINT IF EXISTS applications_salary_range,
+--     DROP COLUMN IF EXISTS job_url,
+--     DROP COLUMN IF EXISTS location,
+--     DROP COLUMN IF EXISTS work_mode,
+--     DROP COLUMN IF EXISTS salary_min,
+--     DROP COLUMN IF EXISTS salary_max,
+--     DROP COLUMN IF EXISTS salary_currency,
+--     DROP COLUMN IF EXISTS employment_type,
+--     DROP COLUMN IF EXISTS source,
+--     DROP COLUMN IF EXISTS priority,
+--     DROP COLUMN IF EXISTS job_description;
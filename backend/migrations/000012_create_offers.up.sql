@@ -0,0 +1,25 @@
+CREATE TABLE offers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    application_id UUID NOT NULL UNIQUE REFERENCES applications(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL,
+    base_salary INTEGER NOT NULL CHECK (base_salary >= 0),
+    pay_period VARCHAR(20) NOT NULL DEFAULT 'yearly',
+    bonus INTEGER NOT NULL DEFAULT 0 CHECK (bonus >= 0),
+    signing_bonus INTEGER NOT NULL DEFAULT 0 CHECK (signing_bonus >= 0),
+    equity JSONB,
+    benefits TEXT[] NOT NULL DEFAULT '{}',
+    start_date DATE,
+    decision_deadline TIMESTAMPTZ,
+    decision VARCHAR(20) NOT NULL DEFAULT 'pending',
+    negotiations JSONB NOT NULL DEFAULT '[]',
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON offers (user_id, decision_deadline) WHERE decision = 'pending';
+
+-- -- migrations/000012_create_offers.down.sql
+
+-- DROP TABLE IF EXISTS offers;
@@ -0,0 +1,24 @@
+CREATE TABLE tags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(7) NOT NULL DEFAULT '#6b7280',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX ON tags (user_id, lower(name));
+
+CREATE TABLE application_tags (
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (application_id, tag_id)
+);
+
+CREATE INDEX ON application_tags (tag_id);
+
+-- -- migrations/000013_create_tags.down.sql
+
+-- DROP TABLE IF EXISTS application_tags;
+-- DROP TABLE IF EXISTS tags;
@@ -0,0 +1,22 @@
+CREATE TABLE custom_fields (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, key)
+);
+
+-- Values are keyed by custom_fields.key. jsonb_path_ops keeps the index small and
+-- serves the @> containment queries used by the list filters.
+ALTER TABLE applications ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX ON applications USING GIN (custom_fields jsonb_path_ops);
+
+-- -- migrations/000014_create_custom_fields.down.sql
+
+-- ALTER TABLE applications DROP COLUMN IF EXISTS custom_fields;
+-- DROP TABLE IF EXISTS custom_fields;
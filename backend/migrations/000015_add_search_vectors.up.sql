@@ -0,0 +1,22 @@
+-- Generated tsvector columns for GET /api/search. The 'english' configuration stems
+-- words, so "interviews" also finds "interview"; company names rank above the role.
+ALTER TABLE applications ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', company), 'A') || setweight(to_tsvector('english', role), 'B')
+    ) STORED;
+
+ALTER TABLE notes ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('english', content)) STORED;
+
+ALTER TABLE history_events ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('english', event || ' ' || COALESCE(reason, ''))) STORED;
+
+CREATE INDEX ON applications USING GIN (search_vector);
+CREATE INDEX ON notes USING GIN (search_vector);
+CREATE INDEX ON history_events USING GIN (search_vector);
+
+-- -- migrations/000015_add_search_vectors.down.sql
+
+-- ALTER TABLE history_events DROP COLUMN IF EXISTS search_vector;
+-- ALTER TABLE notes DROP COLUMN IF EXISTS search_vector;
+-- ALTER TABLE applications DROP COLUMN IF EXISTS search_vector;
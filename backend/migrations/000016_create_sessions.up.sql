@@ -0,0 +1,20 @@
+-- One row per logged-in device. The refresh token is "<session id>.<secret>"; only the
+-- hash of the current secret is kept, and it changes on every refresh. Presenting an
+-- older secret means the token was copied, so the whole session is revoked.
+CREATE TABLE sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions(user_id) WHERE revoked_at IS NULL;
+
+-- -- migrations/000016_create_sessions.down.sql
+
+-- DROP TABLE IF EXISTS sessions;
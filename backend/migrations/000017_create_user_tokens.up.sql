@@ -0,0 +1,20 @@
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMPTZ;
+
+-- Single-use tokens emailed to users, for verifying their address and resetting their
+-- password. Only the hash is stored; a token is spent by setting used_at.
+CREATE TABLE user_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose VARCHAR(20) NOT NULL CHECK (purpose IN ('verify_email', 'reset_password')),
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_user_tokens_user_id ON user_tokens(user_id, purpose) WHERE used_at IS NULL;
+
+-- -- migrations/000017_create_user_tokens.down.sql
+
+-- DROP TABLE IF EXISTS user_tokens;
+-- ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
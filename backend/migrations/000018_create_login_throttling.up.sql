@@ -0,0 +1,39 @@
+-- Hit counters for sliding-window rate limits, one row per key and fixed window. The
+-- limiter weighs the previous window's count by how much of it still overlaps the
+-- sliding window, so two rows per key are enough.
+CREATE TABLE rate_limit_windows (
+    key TEXT NOT NULL,
+    window_start TIMESTAMPTZ NOT NULL,
+    hits INT NOT NULL,
+    PRIMARY KEY (key, window_start)
+);
+
+-- Consecutive failed logins per username, as typed and lowercased. Not a foreign key:
+-- usernames that don't exist are locked out the same way, so lockouts don't reveal
+-- which accounts exist.
+CREATE TABLE login_lockouts (
+    username TEXT PRIMARY KEY,
+    failures INT NOT NULL,
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    locked_until TIMESTAMPTZ
+);
+
+-- Audit log of failed logins
+CREATE TABLE login_attempts (
+    id UUID PRIMARY KEY,
+    username TEXT NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- NULL when no such user exists
+    ip TEXT NOT NULL,
+    user_agent TEXT NOT NULL,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('unknown_user', 'wrong_password', 'locked_out')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_attempts_username ON login_attempts(username, created_at DESC);
+CREATE INDEX idx_login_attempts_created_at ON login_attempts(created_at);
+
+-- -- migrations/000018_create_login_throttling.down.sql
+
+-- DROP TABLE IF EXISTS login_attempts;
+-- DROP TABLE IF EXISTS login_lockouts;
+-- DROP TABLE IF EXISTS rate_limit_windows;
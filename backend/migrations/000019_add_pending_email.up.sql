@@ -0,0 +1,14 @@
+-- A new email address only replaces the current one once the link sent to it is opened
+ALTER TABLE users ADD COLUMN pending_email VARCHAR(255);
+
+ALTER TABLE user_tokens DROP CONSTRAINT user_tokens_purpose_check;
+ALTER TABLE user_tokens ADD CONSTRAINT user_tokens_purpose_check
+    CHECK (purpose IN ('verify_email', 'reset_password', 'change_email'));
+
+-- -- migrations/000019_add_pending_email.down.sql
+
+-- DELETE FROM user_tokens WHERE purpose = 'change_email';
+-- ALTER TABLE user_tokens DROP CONSTRAINT user_tokens_purpose_check;
+-- ALTER TABLE user_tokens ADD CONSTRAINT user_tokens_purpose_check
+--     CHECK (purpose IN ('verify_email', 'reset_password'));
+-- ALTER TABLE users DROP COLUMN IF EXISTS pending_email;
@@ -0,0 +1,10 @@
+-- Hashes of the refresh token secrets a session has rotated through, newest first. Only
+-- one of these being presented again proves the token was copied; any other wrong secret
+-- is just rejected, so knowing a session's ID isn't enough to revoke it.
+ALTER TABLE sessions ADD COLUMN previous_token_hashes TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE sessions ADD COLUMN rotated_at TIMESTAMPTZ;
+
+-- -- migrations/000020_add_session_token_history.down.sql
+
+-- ALTER TABLE sessions DROP COLUMN IF EXISTS rotated_at;
+-- ALTER TABLE sessions DROP COLUMN IF EXISTS previous_token_hashes;
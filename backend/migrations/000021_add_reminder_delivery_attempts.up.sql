@@ -0,0 +1,9 @@
+-- Failed reminder deliveries are retried with a growing delay, and given up on after a
+-- fixed number of attempts, instead of being retried on every scheduler run forever.
+ALTER TABLE reminders ADD COLUMN delivery_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE reminders ADD COLUMN next_attempt_at TIMESTAMPTZ;
+
+-- -- migrations/000021_add_reminder_delivery_attempts.down.sql
+
+-- ALTER TABLE reminders DROP COLUMN IF EXISTS next_attempt_at;
+-- ALTER TABLE reminders DROP COLUMN IF EXISTS delivery_attempts;
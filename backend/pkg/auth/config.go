@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// JWTConfigFromEnv builds the JWT configuration from the environment.
+//
+//	JWT_ALGORITHM          HS256 (default), RS256 or EdDSA
+//	JWT_SECRET             HS256 secret, at least 32 bytes; or JWT_SECRET_FILE to read it from a file
+//	JWT_PRIVATE_KEY        PEM private key for RS256/EdDSA; or JWT_PRIVATE_KEY_FILE
+//	JWT_KEY_ID             "kid" of the signing key; derived from the key when unset
+//	JWT_VERIFICATION_KEYS  Comma-separated files holding keys that are no longer used to sign
+//	                       but are still accepted: PEM keys, or HS256 secrets. Used for rotation.
+//	                       Write "kid=path" for a key whose ID was set through JWT_KEY_ID.
+//	JWT_ISSUER             Defaults to "joblog"
+//	JWT_AUDIENCE           Defaults to "joblog-api"
+//	JWT_ACCESS_TOKEN_TTL   Go duration, defaults to 15m
+func JWTConfigFromEnv() (JWTConfig, error) {
+	config := JWTConfig{
+		Issuer:        envOr("JWT_ISSUER", "joblog"),
+		Audience:      envOr("JWT_AUDIENCE", "joblog-api"),
+		TokenDuration: 15 * time.Minute,
+	}
+	if v := os.Getenv("JWT_ACCESS_TOKEN_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return JWTConfig{}, fmt.Errorf("invalid JWT_ACCESS_TOKEN_TTL %q", v)
+		}
+		config.TokenDuration = d
+	}
+
+	keyID := os.Getenv("JWT_KEY_ID")
+	switch algorithm := envOr("JWT_ALGORITHM", HS256); algorithm {
+	case HS256:
+		secret, err := envOrFile("JWT_SECRET")
+		if err != nil {
+			return JWTConfig{}, err
+		}
+		if secret == nil {
+			return JWTConfig{}, fmt.Errorf("JWT_SECRET or JWT_SECRET_FILE must be set for HS256")
+		}
+		if config.SigningKey, err = NewHMACKey(keyID, secret); err != nil {
+			return JWTConfig{}, fmt.Errorf("JWT_SECRET: %w", err)
+		}
+	case RS256, EdDSA:
+		pemData, err := envOrFile("JWT_PRIVATE_KEY")
+		if err != nil {
+			return JWTConfig{}, err
+		}
+		if pemData == nil {
+			return JWTConfig{}, fmt.Errorf("JWT_PRIVATE_KEY or JWT_PRIVATE_KEY_FILE must be set for %s", algorithm)
+		}
+		key, err := ParseKeyPEM(keyID, pemData)
+		if err != nil {
+			return JWTConfig{}, fmt.Errorf("JWT_PRIVATE_KEY: %w", err)
+		}
+		if !key.CanSign() || key.Algorithm != algorithm {
+			return JWTConfig{}, fmt.Errorf("JWT_PRIVATE_KEY must be a %s private key", algorithm)
+		}
+		config.SigningKey = key
+	default:
+		return JWTConfig{}, fmt.Errorf("unsupported JWT_ALGORITHM %q", algorithm)
+	}
+
+	for _, path := range strings.Split(os.Getenv("JWT_VERIFICATION_KEYS"), ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		id, file, ok := strings.Cut(path, "=")
+		if !ok {
+			id, file = "", path
+		}
+		key, err := readVerificationKey(id, file)
+		if err != nil {
+			return JWTConfig{}, fmt.Errorf("JWT_VERIFICATION_KEYS: %s: %w", path, err)
+		}
+		config.VerificationKeys = append(config.VerificationKeys, key)
+	}
+	return config, nil
+}
+
+// readVerificationKey reads a PEM key, or failing that an HS256 secret. Without an id,
+// it is derived from the key, the same way as for the signing key.
+func readVerificationKey(id, path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Contains(data, []byte("-----BEGIN")) {
+		return ParseKeyPEM(id, data)
+	}
+	return NewHMACKey(id, bytes.TrimSpace(data))
+}
+
+func envOr(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// envOrFile returns the variable's value, or the trimmed contents of the file named by
+// NAME_FILE, as with Docker secrets. It returns nil if neither is set.
+func envOrFile(name string) ([]byte, error) {
+	if v := os.Getenv(name); v != "" {
+		return []byte(v), nil
+	}
+	path := os.Getenv(name + "_FILE")
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s_FILE: %w", name, err)
+	}
+	return bytes.TrimSpace(data), nil
+}
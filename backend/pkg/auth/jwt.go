@@ -3,6 +3,7 @@ package auth
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"joblog/internal/core/domain"
@@ -11,44 +12,108 @@ import (
 )
 
 type JWTManager struct {
-	secretKey     string
+	signingKey    *Key
+	keys          map[string]*Key // By ID; every key tokens are accepted from, including the signing key
+	issuer        string
+	audience      string
 	tokenDuration time.Duration
 }
 
+// JWTConfig configures a JWTManager. To rotate keys without logging anyone out, make the
+// new key the signing key and keep the old one in VerificationKeys until the tokens
+// signed with it have expired.
+type JWTConfig struct {
+	SigningKey       *Key
+	VerificationKeys []*Key // Keys still accepted besides the signing key
+	Issuer           string // Set as "iss" and required when verifying
+	Audience         string // Set as "aud" and required when verifying
+	TokenDuration    time.Duration
+}
+
 type UserClaims struct {
 	jwt.RegisteredClaims
-	UserID   string `json:"userID"`
-	Username string `json:"username"`
+	UserID    string `json:"userID"`
+	Username  string `json:"username"`
+	SessionID string `json:"sid"` // The login session the token was issued for
 }
 
-func NewJWTManager(secretKey string, tokenDuration time.Duration) *JWTManager {
-	return &JWTManager{secretKey: secretKey, tokenDuration: tokenDuration}
+// clockSkew is how far the clocks of the servers issuing and verifying a token may differ.
+const clockSkew = 30 * time.Second
+
+func NewJWTManager(config JWTConfig) (*JWTManager, error) {
+	if config.SigningKey == nil || !config.SigningKey.CanSign() {
+		return nil, fmt.Errorf("a private signing key is required")
+	}
+	if config.Issuer == "" || config.Audience == "" {
+		return nil, fmt.Errorf("an issuer and an audience are required")
+	}
+	if config.TokenDuration <= 0 {
+		return nil, fmt.Errorf("the token duration must be positive")
+	}
+
+	m := &JWTManager{
+		signingKey:    config.SigningKey,
+		keys:          map[string]*Key{config.SigningKey.ID: config.SigningKey},
+		issuer:        config.Issuer,
+		audience:      config.Audience,
+		tokenDuration: config.TokenDuration,
+	}
+	for _, key := range config.VerificationKeys {
+		if _, ok := m.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key ID %q", key.ID)
+		}
+		m.keys[key.ID] = key
+	}
+	return m, nil
 }
 
-func (m *JWTManager) Generate(user *domain.User) (string, error) {
+// Generate issues an access token for the user's session and returns it with its expiry.
+func (m *JWTManager) Generate(user *domain.User, sessionID string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.tokenDuration)
 	claims := UserClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.tokenDuration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    m.issuer,
+			Subject:   user.ID,
+			Audience:  jwt.ClaimStrings{m.audience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
-		UserID:   user.ID,
-		Username: user.Username,
+		UserID:    user.ID,
+		Username:  user.Username,
+		SessionID: sessionID,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(m.secretKey))
+	token := jwt.NewWithClaims(m.signingKey.signingMethod(), claims)
+	token.Header["kid"] = m.signingKey.ID
+	signed, err := token.SignedString(m.signingKey.private)
+	return signed, expiresAt, err
 }
 
+// Verify checks the token's signature against the key named by its "kid" header, and its
+// issuer, audience, expiry and not-before time.
 func (m *JWTManager) Verify(tokenString string) (*UserClaims, error) {
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&UserClaims{},
 		func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			kid, _ := token.Header["kid"].(string)
+			key, ok := m.keys[kid]
+			if !ok {
+				return nil, fmt.Errorf("unknown signing key %q", kid)
+			}
+			// The algorithm must be the key's, so a public key can't be passed off as an HMAC secret
+			if token.Method.Alg() != key.Algorithm {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 			}
-			return []byte(m.secretKey), nil
+			return key.public, nil
 		},
+		jwt.WithValidMethods([]string{HS256, RS256, EdDSA}),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithAudience(m.audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
 	)
 
 	if err != nil {
@@ -62,3 +127,16 @@ func (m *JWTManager) Verify(tokenString string) (*UserClaims, error) {
 
 	return claims, nil
 }
+
+// JWKS returns the public keys tokens may be signed with, for other services to verify
+// them. HMAC keys are secret and never included.
+func (m *JWTManager) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range m.keys {
+		if jwk, ok := key.JWK(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+	return set
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"strings"
+	"testing"
+	"time"
+
+	"joblog/internal/core/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testIssuer   = "joblog"
+	testAudience = "joblog-api"
+)
+
+func newTestManager(t *testing.T, signingKey *Key, verificationKeys ...*Key) *JWTManager {
+	t.Helper()
+	m, err := NewJWTManager(JWTConfig{
+		SigningKey:       signingKey,
+		VerificationKeys: verificationKeys,
+		Issuer:           testIssuer,
+		Audience:         testAudience,
+		TokenDuration:    time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func testClaims(issuer, audience string, expiresAt time.Time) UserClaims {
+	return UserClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   "user-1",
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		UserID:    "user-1",
+		SessionID: "session-1",
+	}
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, secret any, claims UserClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestJWTManagerVerify(t *testing.T) {
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := ParseKeyPEM("rsa", encodePEM("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPrivate)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPublicPEM := pkixPEM(t, &rsaPrivate.PublicKey)
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldKey, err := NewHMACKey("old", []byte(strings.Repeat("o", minHMACSecretLength)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	strangerSecret := []byte(strings.Repeat("x", minHMACSecretLength))
+
+	m := newTestManager(t, rsaKey, oldKey)
+	generated, _, err := m.Generate(&domain.User{ID: "user-1", Username: "ada"}, "session-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := func() UserClaims { return testClaims(testIssuer, testAudience, time.Now().Add(time.Hour)) }
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"generated", generated, false},
+		{"signed with a verification key", signToken(t, jwt.SigningMethodHS256, "old", []byte(strings.Repeat("o", minHMACSecretLength)), valid()), false},
+		{"unknown kid", signToken(t, jwt.SigningMethodHS256, "stranger", strangerSecret, valid()), true},
+		{"missing kid", signToken(t, jwt.SigningMethodRS256, "", rsaPrivate, valid()), true},
+		{"wrong key for kid", signToken(t, jwt.SigningMethodHS256, "old", strangerSecret, valid()), true},
+		// A token "signed" with the RSA public key as an HMAC secret must not verify
+		{"HS256 with the RSA public key", signToken(t, jwt.SigningMethodHS256, "rsa", rsaPublicPEM, valid()), true},
+		{"EdDSA under the RSA kid", signToken(t, jwt.SigningMethodEdDSA, "rsa", edPrivate, valid()), true},
+		{"RS256 under the HMAC kid", signToken(t, jwt.SigningMethodRS256, "old", rsaPrivate, valid()), true},
+		{"wrong issuer", signToken(t, jwt.SigningMethodRS256, "rsa", rsaPrivate, testClaims("someone-else", testAudience, time.Now().Add(time.Hour))), true},
+		{"wrong audience", signToken(t, jwt.SigningMethodRS256, "rsa", rsaPrivate, testClaims(testIssuer, "other-api", time.Now().Add(time.Hour))), true},
+		{"expired within clock skew", signToken(t, jwt.SigningMethodRS256, "rsa", rsaPrivate, testClaims(testIssuer, testAudience, time.Now().Add(-clockSkew/2))), false},
+		{"expired", signToken(t, jwt.SigningMethodRS256, "rsa", rsaPrivate, testClaims(testIssuer, testAudience, time.Now().Add(-time.Minute))), true},
+		{"not a token", "not.a.token", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := m.Verify(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() = %v, want error: %v", err, tt.wantErr)
+			}
+			if err == nil && (claims.UserID != "user-1" || claims.SessionID != "session-1") {
+				t.Errorf("Verify() claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestJWTManagerJWKS(t *testing.T) {
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edKey, err := ParseKeyPEM("", pkcs8PEM(t, edPrivate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hmacKey, err := NewHMACKey("old", []byte(strings.Repeat("o", minHMACSecretLength)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set := newTestManager(t, edKey, hmacKey).JWKS()
+	if len(set.Keys) != 1 || set.Keys[0].KeyID != edKey.ID || set.Keys[0].Algorithm != EdDSA {
+		t.Errorf("JWKS() = %+v, want only the Ed25519 key", set)
+	}
+}
@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms.
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+)
+
+const (
+	minHMACSecretLength = 32 // Bytes; as long as the HS256 hash
+	minRSAKeyBits       = 2048
+)
+
+// Key is a JWT signing or verification key. Keys parsed from a private key can sign;
+// keys parsed from a public key can only verify, as can any key that is not the
+// manager's signing key.
+type Key struct {
+	ID        string // The "kid" header of tokens signed with it
+	Algorithm string
+	private   any // []byte, *rsa.PrivateKey or ed25519.PrivateKey; nil for public keys
+	public    any // []byte, *rsa.PublicKey or ed25519.PublicKey
+}
+
+// NewHMACKey returns an HS256 key. If id is empty, one is derived from the secret.
+func NewHMACKey(id string, secret []byte) (*Key, error) {
+	if len(secret) < minHMACSecretLength {
+		return nil, fmt.Errorf("HS256 secrets must be at least %d bytes long", minHMACSecretLength)
+	}
+	if id == "" {
+		sum := sha256.Sum256(secret)
+		id = hex.EncodeToString(sum[:8])
+	}
+	return &Key{ID: id, Algorithm: HS256, private: secret, public: secret}, nil
+}
+
+// ParseKeyPEM reads an RSA (RS256) or Ed25519 (EdDSA) key from PEM. Private keys may be
+// PKCS#1 or PKCS#8, public keys PKIX. If id is empty, the key's RFC 7638 thumbprint is used.
+func ParseKeyPEM(id string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", block.Type, err)
+	}
+
+	key := &Key{ID: id}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Algorithm, key.private, key.public = RS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Algorithm, key.public = RS256, k
+	case ed25519.PrivateKey:
+		key.Algorithm, key.private, key.public = EdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Algorithm, key.public = EdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T; expected RSA or Ed25519", parsed)
+	}
+	if rsaKey, ok := key.public.(*rsa.PublicKey); ok && rsaKey.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("RSA keys must be at least %d bits", minRSAKeyBits)
+	}
+
+	if key.ID == "" {
+		key.ID, err = key.thumbprint()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// CanSign reports whether the key holds the private half needed to sign tokens.
+func (k *Key) CanSign() bool {
+	return k.private != nil
+}
+
+func (k *Key) signingMethod() jwt.SigningMethod {
+	switch k.Algorithm {
+	case RS256:
+		return jwt.SigningMethodRS256
+	case EdDSA:
+		return jwt.SigningMethodEdDSA
+	default:
+		return jwt.SigningMethodHS256
+	}
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA exponent
+	Curve     string `json:"crv,omitempty"` // OKP curve
+	X         string `json:"x,omitempty"`   // OKP public key
+}
+
+// JWKSet is the document served at /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK returns the public half of the key. HMAC keys are secret and have none.
+func (k *Key) JWK() (JWK, bool) {
+	b64 := base64.RawURLEncoding.EncodeToString
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		return JWK{KeyType: "RSA", KeyID: k.ID, Use: "sig", Algorithm: k.Algorithm,
+			N: b64(public.N.Bytes()), E: b64(big.NewInt(int64(public.E)).Bytes())}, true
+	case ed25519.PublicKey:
+		return JWK{KeyType: "OKP", KeyID: k.ID, Use: "sig", Algorithm: k.Algorithm, Curve: "Ed25519", X: b64(public)}, true
+	default:
+		return JWK{}, false
+	}
+}
+
+// thumbprint computes the RFC 7638 thumbprint of a public key: the hash of its required
+// JWK members, in lexicographic order.
+func (k *Key) thumbprint() (string, error) {
+	jwk, ok := k.JWK()
+	if !ok {
+		return "", fmt.Errorf("only public keys have a thumbprint")
+	}
+	var members any
+	if jwk.KeyType == "RSA" {
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.KeyType, jwk.N}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Curve, jwk.KeyType, jwk.X}
+	}
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+)
+
+func encodePEM(blockType string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func pkcs8PEM(t *testing.T, key any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encodePEM("PRIVATE KEY", der)
+}
+
+func pkixPEM(t *testing.T, key any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encodePEM("PUBLIC KEY", der)
+}
+
+func TestParseKeyPEM(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smallRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		data          []byte
+		wantAlgorithm string
+		wantCanSign   bool
+		wantErr       string
+	}{
+		{"RSA PKCS#1", encodePEM("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)), RS256, true, ""},
+		{"RSA PKCS#8", pkcs8PEM(t, rsaKey), RS256, true, ""},
+		{"RSA public", pkixPEM(t, &rsaKey.PublicKey), RS256, false, ""},
+		{"Ed25519 private", pkcs8PEM(t, edPrivate), EdDSA, true, ""},
+		{"Ed25519 public", pkixPEM(t, edPublic), EdDSA, false, ""},
+		{"RSA too small", encodePEM("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(smallRSAKey)), "", false, "at least 2048 bits"},
+		{"unsupported block", encodePEM("CERTIFICATE", []byte("not a certificate")), "", false, "unsupported PEM block"},
+		{"bad key data", encodePEM("PRIVATE KEY", []byte("not a key")), "", false, "could not parse"},
+		{"not PEM", []byte("-----nothing here-----"), "", false, "no PEM data"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseKeyPEM("", tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseKeyPEM() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseKeyPEM() = %v", err)
+			}
+			if key.Algorithm != tt.wantAlgorithm || key.CanSign() != tt.wantCanSign {
+				t.Errorf("ParseKeyPEM() = %s key, can sign: %v; want %s, %v", key.Algorithm, key.CanSign(), tt.wantAlgorithm, tt.wantCanSign)
+			}
+			if key.ID == "" {
+				t.Error("ParseKeyPEM() left the key ID empty")
+			}
+		})
+	}
+}
+
+func TestParseKeyPEMThumbprint(t *testing.T) {
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	private, err := ParseKeyPEM("", pkcs8PEM(t, edPrivate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, err := ParseKeyPEM("", pkixPEM(t, edPublic))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if private.ID != public.ID {
+		t.Errorf("private key ID %q differs from public key ID %q", private.ID, public.ID)
+	}
+
+	named, err := ParseKeyPEM("2026-01", pkixPEM(t, edPublic))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if named.ID != "2026-01" {
+		t.Errorf("ParseKeyPEM() ID = %q, want the one given", named.ID)
+	}
+}
+
+func TestNewHMACKey(t *testing.T) {
+	if _, err := NewHMACKey("", make([]byte, minHMACSecretLength-1)); err == nil {
+		t.Error("NewHMACKey() accepted a short secret")
+	}
+	key, err := NewHMACKey("", []byte(strings.Repeat("s", minHMACSecretLength)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.Algorithm != HS256 || key.ID == "" || !key.CanSign() {
+		t.Errorf("NewHMACKey() = %+v", key)
+	}
+	if _, ok := key.JWK(); ok {
+		t.Error("HMAC keys must not have a public JWK")
+	}
+}
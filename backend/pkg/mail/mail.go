@@ -0,0 +1,113 @@
+// Package mail sends transactional emails, such as password reset links.
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string // Plain text
+}
+
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// LogSender writes emails to the standard logger instead of sending them. The tokens in
+// links are redacted, since whoever reads the logs could otherwise take over accounts;
+// use a FileSender to follow the links in development.
+type LogSender struct{}
+
+var linkToken = regexp.MustCompile(`token=[^&\s]+`)
+
+func (LogSender) Send(ctx context.Context, msg Message) error {
+	log.Printf("[mail] to=%s subject=%q\n%s", msg.To, msg.Subject, linkToken.ReplaceAllString(msg.Body, "token=[redacted]"))
+	return nil
+}
+
+// FileSender writes each email to its own .eml file in a directory, where it can be
+// opened with a mail client. Useful for local development.
+type FileSender struct {
+	dir  string
+	from string
+	seq  atomic.Int64 // Keeps names unique within the same nanosecond
+}
+
+func NewFileSender(dir, from string) (*FileSender, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("could not create mail directory: %w", err)
+	}
+	return &FileSender{dir: dir, from: from}, nil
+}
+
+func (s *FileSender) Send(ctx context.Context, msg Message) error {
+	name := fmt.Sprintf("%s-%d.eml", time.Now().UTC().Format("20060102T150405.000000000"), s.seq.Add(1))
+	if err := os.WriteFile(filepath.Join(s.dir, name), format(s.from, msg), 0o644); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	return nil
+}
+
+// FromEnv builds the sender selected by MAILER ("log", "file" or "smtp"). There is no
+// default, so a deployment can't end up not sending account emails by accident.
+//
+//	file: MAIL_DIR (defaults to ./mail), MAIL_FROM
+//	smtp: MAIL_FROM or SMTP_FROM, and the relay settings read by SMTPFromEnv
+func FromEnv() (Sender, error) {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = os.Getenv("SMTP_FROM")
+	}
+
+	switch kind := os.Getenv("MAILER"); kind {
+	case "":
+		return nil, fmt.Errorf("MAILER must be set to log, file or smtp")
+	case "log":
+		return LogSender{}, nil
+	case "file":
+		dir := os.Getenv("MAIL_DIR")
+		if dir == "" {
+			dir = "./mail"
+		}
+		if from == "" {
+			from = "JobLog <noreply@joblog.local>"
+		}
+		return NewFileSender(dir, from)
+	case "smtp":
+		if from == "" {
+			return nil, fmt.Errorf("MAIL_FROM (or SMTP_FROM) must be set for the smtp mailer")
+		}
+		return SMTPFromEnv(from)
+	default:
+		return nil, fmt.Errorf("unknown mailer %q", kind)
+	}
+}
+
+// format renders the message as a plain-text RFC 5322 email.
+func format(from string, msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", headerSafe(msg.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerSafe(msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// headerSafe stops user-provided text from injecting extra headers.
+func headerSafe(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
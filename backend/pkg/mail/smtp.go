@@ -0,0 +1,55 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"os"
+)
+
+// SMTPSender delivers emails through an SMTP relay.
+type SMTPSender struct {
+	addr string
+	from string
+	auth smtp.Auth // nil when the relay doesn't require authentication
+}
+
+func NewSMTPSender(addr, from, username, password string) *SMTPSender {
+	s := &SMTPSender{addr: addr, from: from}
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return s
+}
+
+// SMTPFromEnv builds a sender for the relay at SMTP_ADDR (host:port), logging in with
+// SMTP_USERNAME / SMTP_PASSWORD if set. Account emails and reminder notifications share it.
+func SMTPFromEnv(from string) (*SMTPSender, error) {
+	addr := os.Getenv("SMTP_ADDR")
+	if addr == "" {
+		return nil, fmt.Errorf("SMTP_ADDR must be set to send email over SMTP")
+	}
+	return NewSMTPSender(addr, from, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD")), nil
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	if msg.To == "" {
+		return fmt.Errorf("message has no recipient")
+	}
+	if err := smtp.SendMail(s.addr, s.auth, envelopeAddress(s.from), []string{msg.To}, format(s.from, msg)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// envelopeAddress strips the display name from a From header such as
+// "JobLog <noreply@example.com>", which SMTP's MAIL FROM doesn't accept.
+func envelopeAddress(from string) string {
+	if addr, err := netmail.ParseAddress(from); err == nil {
+		return addr.Address
+	}
+	return from
+}
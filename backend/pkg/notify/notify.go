@@ -0,0 +1,60 @@
+// Package notify delivers short messages to users over a pluggable channel.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"joblog/pkg/mail"
+)
+
+type Message struct {
+	To      string // Recipient address, e.g. the user's email
+	Subject string
+	Body    string
+	Meta    map[string]string // Extra machine-readable context, e.g. IDs; passed through by webhooks
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// LogNotifier writes messages to the standard logger. Useful for local development.
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, msg Message) error {
+	log.Printf("[notify] to=%s subject=%q body=%q meta=%v", msg.To, msg.Subject, msg.Body, msg.Meta)
+	return nil
+}
+
+// FromEnv builds the notifier selected by NOTIFIER ("log", "smtp" or "webhook"), defaulting to log.
+//
+//	smtp:    SMTP_FROM, and the relay settings read by mail.SMTPFromEnv
+//	webhook: NOTIFY_WEBHOOK_URL
+func FromEnv() (Notifier, error) {
+	switch kind := os.Getenv("NOTIFIER"); kind {
+	case "", "log":
+		return LogNotifier{}, nil
+	case "smtp":
+		from := os.Getenv("SMTP_FROM")
+		if from == "" {
+			return nil, fmt.Errorf("SMTP_FROM must be set for the smtp notifier")
+		}
+		sender, err := mail.SMTPFromEnv(from)
+		if err != nil {
+			return nil, err
+		}
+		return NewSMTPNotifier(sender), nil
+	case "webhook":
+		url := os.Getenv("NOTIFY_WEBHOOK_URL")
+		if url == "" {
+			return nil, fmt.Errorf("NOTIFY_WEBHOOK_URL must be set for the webhook notifier")
+		}
+		return NewWebhookNotifier(url, 10*time.Second), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", kind)
+	}
+}
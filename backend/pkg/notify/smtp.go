@@ -0,0 +1,21 @@
+package notify
+
+import (
+	"context"
+
+	"joblog/pkg/mail"
+)
+
+// SMTPNotifier sends each message as a plain-text email, through the same SMTP sender as
+// account emails.
+type SMTPNotifier struct {
+	sender *mail.SMTPSender
+}
+
+func NewSMTPNotifier(sender *mail.SMTPSender) *SMTPNotifier {
+	return &SMTPNotifier{sender: sender}
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, msg Message) error {
+	return n.sender.Send(ctx, mail.Message{To: msg.To, Subject: msg.Subject, Body: msg.Body})
+}
@@ -14,6 +14,8 @@ const ApplicationDetail = React.lazy(() => import('./pages/ApplicationDetail'));
 const Blog = React.lazy(() => import('./pages/Blog'));
 const BlogEditor = React.lazy(() => import('./pages/BlogEditor'));
 const BlogPost = React.lazy(() => import('./pages/BlogPost'));
+const VerifyEmail = React.lazy(() => import('./pages/VerifyEmail'));
+const ResetPassword = React.lazy(() => import('./pages/ResetPassword'));
 
 function App() {
   return (
@@ -31,6 +33,9 @@ function App() {
               <Routes>
                 {/* Public Routes */}
                 <Route path="/" element={<LandingPage />} />
+                {/* Opened from the links in account emails */}
+                <Route path="/verify-email" element={<VerifyEmail />} />
+                <Route path="/reset-password" element={<ResetPassword />} />
                 
                 {/* Protected Routes */}
                 <Route element={<ProtectedRoute />}>
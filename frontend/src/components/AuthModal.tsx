@@ -1,5 +1,6 @@
 import React, { useState, useEffect } from 'react';
 import { motion, AnimatePresence } from 'framer-motion';
+import { Link } from 'react-router-dom';
 import { useAuth } from '../context/AuthContext';
 import { X, Loader } from 'lucide-react';
 
@@ -184,6 +185,13 @@ export default function AuthModal({ isOpen, onClose, initialTab = 'signin' }: Au
                     onChange={handleInputChange}
                     placeholder="Enter your password"
                   />
+                  <Link
+                    to="/reset-password"
+                    onClick={onClose}
+                    className="inline-block mt-1 text-xs text-[var(--muted-foreground)] hover:text-[var(--foreground)]"
+                  >
+                    Forgot your password?
+                  </Link>
                 </div>
                 <button
                   type="submit"
@@ -1,6 +1,6 @@
 import React, { createContext, useContext, useReducer, useEffect, ReactNode } from 'react';
 import { AuthState, AuthAction } from '../types';
-import { login as apiLogin, register as apiRegister } from "../services/api/authService"
+import { login as apiLogin, logout as apiLogout, register as apiRegister } from "../services/api/authService"
 
 interface AuthContextType {
   state: AuthState;
@@ -122,8 +122,8 @@ export function AuthProvider({ children }: AuthProviderProps) {
         throw new Error('Passwords do not match');
       }
 
-      if (password.length < 6) {
-        throw new Error('Password must be at least 6 characters');
+      if (password.length < 8) {
+        throw new Error('Password must be at least 8 characters');
       }
 
       const resp = await apiRegister({ email, password, username })
@@ -149,6 +149,8 @@ export function AuthProvider({ children }: AuthProviderProps) {
   };
 
   const logout = () => {
+    // Revoke the session server-side; the local state is cleared either way
+    apiLogout().catch(() => {});
     sessionStorage.removeItem('joblog_token');
     sessionStorage.removeItem('joblog_user');
     dispatch({ type: 'LOGOUT' });
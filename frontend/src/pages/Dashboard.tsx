@@ -10,14 +10,23 @@ import {
   TrendingUp
 } from 'lucide-react';
 import { BarChart, Bar, XAxis, YAxis, CartesianGrid, Tooltip, ResponsiveContainer, PieChart, Pie, Cell } from 'recharts';
-// TODO: update this
-import { getDashboardStats } from '../api/dashboard';
-import { DashboardStats } from '../types';
+import { getApplicationStats, getRecentApplications } from '../services/api/applicationService';
+import { getAllBlogPosts } from '../services/api/blogService';
+import type { Application, ApplicationStats, ApplicationStatus, BlogPost } from '../services/api/types';
 import LoadingSpinner from '../components/LoadingSpinner';
 import StatusBadge from '../components/StatusBadge';
 
+// Stages between applying and hearing back, counted as "In Progress"
+const IN_PROGRESS: ApplicationStatus[] = ['Phone Screen', 'Take-home', 'Interviewing', 'Onsite'];
+
+interface DashboardData {
+  stats: ApplicationStats;
+  recentApplications: Application[];
+  recentBlogPosts: BlogPost[];
+}
+
 export default function Dashboard() {
-  const [stats, setStats] = useState<DashboardStats | null>(null);
+  const [data, setData] = useState<DashboardData | null>(null);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState<string | null>(null);
 
@@ -26,9 +35,16 @@ export default function Dashboard() {
   }, []);
 
   const loadDashboardStats = async () => {
+    setLoading(true);
+    setError(null);
     try {
-      const response = await getDashboardStats();
-      setStats(response.data);
+      // The counts come from the server, so only the handful of items shown are fetched
+      const [stats, recentApplications, blogPosts] = await Promise.all([
+        getApplicationStats(),
+        getRecentApplications(5),
+        getAllBlogPosts(),
+      ]);
+      setData({ stats, recentApplications, recentBlogPosts: blogPosts.slice(0, 3) });
     } catch (err) {
       setError('Failed to load dashboard data');
     } finally {
@@ -44,7 +60,7 @@ export default function Dashboard() {
     );
   }
 
-  if (error || !stats) {
+  if (error || !data) {
     return (
       <div className="min-h-screen flex items-center justify-center">
         <div className="text-center">
@@ -60,19 +76,23 @@ export default function Dashboard() {
     );
   }
 
-  const chartData = Object.entries(stats.statusBreakdown).map(([status, count]) => ({
+  const { stats, recentApplications, recentBlogPosts } = data;
+  const countOf = (status: ApplicationStatus) => stats.statusBreakdown[status] ?? 0;
+  const inProgress = IN_PROGRESS.reduce((sum, status) => sum + countOf(status), 0);
+
+  const chartData = (Object.keys(stats.statusBreakdown) as ApplicationStatus[]).map((status) => ({
     status,
-    count,
+    count: countOf(status),
   }));
 
-  const pieData = Object.entries(stats.statusBreakdown)
-    .filter(([, count]) => count > 0)
-    .map(([status, count]) => ({
+  const pieData = chartData
+    .filter(({ count }) => count > 0)
+    .map(({ status, count }) => ({
       name: status,
       value: count,
     }));
 
-  const colors = {
+  const colors: Partial<Record<ApplicationStatus, string>> = {
     Applied: '#3B82F6',
     Interviewing: '#F59E0B',
     Offer: '#10B981',
@@ -121,7 +141,7 @@ export default function Dashboard() {
                 </div>
                 <div>
                   <p className="text-2xl font-bold text-[var(--foreground)]">
-                    {stats.totalApplications}
+                    {stats.total}
                   </p>
                   <p className="text-sm text-[var(--muted-foreground)]">Total Applications</p>
                 </div>
@@ -135,7 +155,7 @@ export default function Dashboard() {
                 </div>
                 <div>
                   <p className="text-2xl font-bold text-[var(--foreground)]">
-                    {stats.interviewing}
+                    {inProgress}
                   </p>
                   <p className="text-sm text-[var(--muted-foreground)]">In Progress</p>
                 </div>
@@ -149,7 +169,7 @@ export default function Dashboard() {
                 </div>
                 <div>
                   <p className="text-2xl font-bold text-[var(--foreground)]">
-                    {stats.offers}
+                    {countOf('Offer')}
                   </p>
                   <p className="text-sm text-[var(--muted-foreground)]">Offers Received</p>
                 </div>
@@ -207,7 +227,7 @@ export default function Dashboard() {
                     {pieData.map((entry, index) => (
                       <Cell
                         key={`cell-${index}`}
-                        fill={colors[entry.name as keyof typeof colors]}
+                        fill={colors[entry.name as ApplicationStatus] ?? 'var(--primary)'}
                       />
                     ))}
                   </Pie>
@@ -240,8 +260,8 @@ export default function Dashboard() {
                 </Link>
               </div>
               <div className="space-y-3">
-                {stats.recentApplications.length > 0 ? (
-                  stats.recentApplications.map((app) => (
+                {recentApplications.length > 0 ? (
+                  recentApplications.map((app) => (
                     <div key={app.id} className="flex items-center justify-between p-3 rounded-lg hover:bg-[var(--muted)] transition-colors">
                       <div className="flex-1">
                         <p className="font-medium text-[var(--foreground)] truncate">
@@ -286,8 +306,8 @@ export default function Dashboard() {
                 </Link>
               </div>
               <div className="space-y-3">
-                {stats.recentBlogPosts.length > 0 ? (
-                  stats.recentBlogPosts.map((post) => (
+                {recentBlogPosts.length > 0 ? (
+                  recentBlogPosts.map((post) => (
                     <Link
                       key={post.id}
                       to={`/blog/${post.slug}`}
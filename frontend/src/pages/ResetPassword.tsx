@@ -0,0 +1,141 @@
+import React, { useState } from 'react';
+import { Link, useSearchParams } from 'react-router-dom';
+import axios from 'axios';
+import { CheckCircle, Loader } from 'lucide-react';
+import { forgotPassword, resetPassword } from '../services/api/authService';
+
+const MIN_PASSWORD_LENGTH = 8;
+
+const errorMessage = (err: unknown, fallback: string): string =>
+  axios.isAxiosError(err) && err.response?.data?.error ? err.response.data.error : fallback;
+
+// Without a token, asks for the email to send a reset link to; opened from that link,
+// sets the new password.
+export default function ResetPassword() {
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get('token');
+  const [email, setEmail] = useState('');
+  const [password, setPassword] = useState('');
+  const [confirmPassword, setConfirmPassword] = useState('');
+  const [loading, setLoading] = useState(false);
+  const [done, setDone] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+
+  const handleRequest = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setLoading(true);
+    setError(null);
+    try {
+      await forgotPassword(email);
+      setDone(true);
+    } catch (err) {
+      setError(errorMessage(err, 'Could not send the reset link.'));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const handleReset = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (password.length < MIN_PASSWORD_LENGTH) {
+      setError(`Password must be at least ${MIN_PASSWORD_LENGTH} characters long.`);
+      return;
+    }
+    if (password !== confirmPassword) {
+      setError('Passwords do not match.');
+      return;
+    }
+    setLoading(true);
+    setError(null);
+    try {
+      await resetPassword(token!, password);
+      setDone(true);
+    } catch (err) {
+      setError(errorMessage(err, 'Could not reset your password.'));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <div className="min-h-screen flex items-center justify-center px-4">
+      <div className="card max-w-md w-full space-y-4">
+        <h1 className="text-2xl font-bold text-[var(--foreground)]">
+          {token ? 'Choose a new password' : 'Reset your password'}
+        </h1>
+
+        {done ? (
+          <div className="text-center space-y-4">
+            <CheckCircle className="w-12 h-12 mx-auto text-green-500" />
+            <p className="text-[var(--muted-foreground)]">
+              {token
+                ? 'Your password was changed and you were logged out everywhere. Sign in with the new password.'
+                : 'If an account uses that email, a reset link is on its way. It works for one hour.'}
+            </p>
+            <Link to="/" className="btn btn-primary w-full py-3 text-base">
+              Continue to JobLog
+            </Link>
+          </div>
+        ) : (
+          <form onSubmit={token ? handleReset : handleRequest} className="space-y-4">
+            {error && (
+              <div className="p-3 bg-red-50 border border-red-200 rounded-lg">
+                <p className="text-sm text-red-600">{error}</p>
+              </div>
+            )}
+            {token ? (
+              <>
+                <div>
+                  <label htmlFor="reset-password" className="label">
+                    New Password
+                  </label>
+                  <input
+                    id="reset-password"
+                    type="password"
+                    required
+                    className="input"
+                    value={password}
+                    onChange={(e) => setPassword(e.target.value)}
+                    placeholder={`At least ${MIN_PASSWORD_LENGTH} characters`}
+                  />
+                </div>
+                <div>
+                  <label htmlFor="reset-confirm-password" className="label">
+                    Confirm Password
+                  </label>
+                  <input
+                    id="reset-confirm-password"
+                    type="password"
+                    required
+                    className="input"
+                    value={confirmPassword}
+                    onChange={(e) => setConfirmPassword(e.target.value)}
+                    placeholder="Confirm your new password"
+                  />
+                </div>
+              </>
+            ) : (
+              <div>
+                <label htmlFor="reset-email" className="label">
+                  Email
+                </label>
+                <input
+                  id="reset-email"
+                  type="email"
+                  required
+                  className="input"
+                  value={email}
+                  onChange={(e) => setEmail(e.target.value)}
+                  placeholder="Enter your account's email"
+                />
+              </div>
+            )}
+            <button type="submit" disabled={loading} className="btn btn-primary w-full py-3 text-base">
+              {loading ? <Loader className="w-5 h-5 animate-spin" /> : token ? 'Set Password' : 'Send Reset Link'}
+            </button>
+          </form>
+        )}
+      </div>
+    </div>
+  );
+}
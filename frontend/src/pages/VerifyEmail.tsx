@@ -0,0 +1,59 @@
+import { useEffect, useRef, useState } from 'react';
+import { Link, useSearchParams } from 'react-router-dom';
+import axios from 'axios';
+import { CheckCircle, XCircle } from 'lucide-react';
+import { verifyEmail } from '../services/api/authService';
+import LoadingSpinner from '../components/LoadingSpinner';
+
+// Landing page of the link in verification emails, which confirm a new account's address
+// or a changed one.
+export default function VerifyEmail() {
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get('token');
+  const [status, setStatus] = useState<'verifying' | 'verified' | 'failed'>(token ? 'verifying' : 'failed');
+  const [error, setError] = useState<string | null>(token ? null : 'This verification link is incomplete.');
+  // Tokens are single use, so React's double-invoked effects in development must not send it twice
+  const sent = useRef(false);
+
+  useEffect(() => {
+    if (!token || sent.current) return;
+    sent.current = true;
+    verifyEmail(token)
+      .then(() => setStatus('verified'))
+      .catch((err) => {
+        setStatus('failed');
+        setError(axios.isAxiosError(err) && err.response?.data?.error
+          ? err.response.data.error
+          : 'Could not verify your email address.');
+      });
+  }, [token]);
+
+  return (
+    <div className="min-h-screen flex items-center justify-center px-4">
+      <div className="card max-w-md w-full text-center space-y-4">
+        {status === 'verifying' && <LoadingSpinner size="lg" text="Verifying your email address..." />}
+        {status === 'verified' && (
+          <>
+            <CheckCircle className="w-12 h-12 mx-auto text-green-500" />
+            <h1 className="text-2xl font-bold text-[var(--foreground)]">Email address confirmed</h1>
+            <p className="text-[var(--muted-foreground)]">Thanks! Your account now uses this address.</p>
+          </>
+        )}
+        {status === 'failed' && (
+          <>
+            <XCircle className="w-12 h-12 mx-auto text-red-500" />
+            <h1 className="text-2xl font-bold text-[var(--foreground)]">Verification failed</h1>
+            <p className="text-[var(--muted-foreground)]">
+              {error} Links expire after 48 hours and work once; you can ask for a new one from your profile.
+            </p>
+          </>
+        )}
+        {status !== 'verifying' && (
+          <Link to="/" className="btn btn-primary w-full py-3 text-base">
+            Continue to JobLog
+          </Link>
+        )}
+      </div>
+    </div>
+  );
+}
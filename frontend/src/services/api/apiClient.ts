@@ -1,6 +1,7 @@
 
 import axios from 'axios';
-import { getToken, removeToken } from './tokenService';
+import { getToken, getRefreshToken, removeToken, setRefreshToken, setToken } from './tokenService';
+import type { AuthResponse } from './types';
 
 // Create a central Axios instance with a base URL
 const apiClient = axios.create({
@@ -23,11 +24,53 @@ apiClient.interceptors.request.use(
   (error) => Promise.reject(error)
 );
 
+// Refreshes the access token. Concurrent 401s share one refresh, since a refresh token
+// only works once and reusing it logs the session out. Other tabs share the stored tokens,
+// so a Web Lock makes them take turns; a tab that finds the token already replaced by
+// another one uses the new tokens instead of refreshing again.
+let refreshing: Promise<string> | null = null;
+
+const REFRESH_LOCK = 'joblog_refresh';
+
+const refreshAccessToken = (refreshToken: string): Promise<string> => {
+  if (!refreshing) {
+    const refresh = async (): Promise<string> => {
+      const stored = getRefreshToken();
+      const token = getToken();
+      if (stored && stored !== refreshToken && token) {
+        return token;
+      }
+      const response = await axios.post<AuthResponse>(`${apiClient.defaults.baseURL}/auth/refresh`, { refreshToken });
+      setToken(response.data.token);
+      setRefreshToken(response.data.refreshToken);
+      return response.data.token;
+    };
+    refreshing = (navigator.locks ? navigator.locks.request(REFRESH_LOCK, refresh) : refresh())
+      .finally(() => {
+        refreshing = null;
+      });
+  }
+  return refreshing;
+};
+
 // |--- Response Interceptor ---
-// Checks for 401 Unauthorized errors to automatically log the user out.
+// On 401 Unauthorized, tries once to refresh the access token and repeat the request;
+// if that fails, the user is logged out.
 apiClient.interceptors.response.use(
   (response) => response,
-  (error) => {
+  async (error) => {
+    const original = error.config;
+    const refreshToken = getRefreshToken();
+    if (error.response?.status === 401 && refreshToken && original && !original._retried) {
+      original._retried = true;
+      try {
+        const token = await refreshAccessToken(refreshToken);
+        original.headers.Authorization = `Bearer ${token}`;
+        return apiClient(original);
+      } catch {
+        // Fall through to logging out
+      }
+    }
     if (error.response?.status === 401) {
       console.error("Unauthorized access - logging out.");
       removeToken(); // Clear the expired/invalid token
@@ -1,14 +1,49 @@
 
 import apiClient from './apiClient';
-import type { Application, NewApplication, ApplicationUpdate, Note } from './types';
+import type { Application, ApplicationStats, NewApplication, ApplicationUpdate, Note } from './types';
 
 // |--- Job Applications ---
 
+// The largest page the server returns
+const PAGE_SIZE = 200;
+
+// Returns the cursor of the next page from a Link header, if there is one.
+const nextCursor = (link: string | undefined): string | null => {
+  const next = link?.match(/<([^>]*)>;\s*rel="next"/);
+  return next ? new URL(next[1], window.location.origin).searchParams.get('cursor') : null;
+};
+
 /**
- * Fetches all job applications for the logged-in user.
+ * Fetches all job applications for the logged-in user, following the server's pages.
  */
 export const getAllApplications = async (): Promise<Application[]> => {
-  const response = await apiClient.get<Application[]>('/applications');
+  const applications: Application[] = [];
+  let cursor: string | null = null;
+  do {
+    const response = await apiClient.get<Application[]>('/applications', {
+      params: { limit: PAGE_SIZE, ...(cursor ? { cursor } : {}) },
+    });
+    applications.push(...response.data);
+    cursor = nextCursor(response.headers.link);
+  } while (cursor);
+  return applications;
+};
+
+/**
+ * Fetches the server-side dashboard statistics for the logged-in user.
+ */
+export const getApplicationStats = async (): Promise<ApplicationStats> => {
+  const response = await apiClient.get<ApplicationStats>('/applications/stats');
+  return response.data;
+};
+
+/**
+ * Fetches the most recently updated job applications.
+ */
+export const getRecentApplications = async (limit: number): Promise<Application[]> => {
+  const response = await apiClient.get<Application[]>('/applications', {
+    params: { sort: '-updatedAt', limit },
+  });
   return response.data;
 };
 
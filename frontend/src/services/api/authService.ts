@@ -1,7 +1,7 @@
 
 import apiClient from './apiClient';
-import { setToken, removeToken } from './tokenService';
-import type { User, UserRegistration, UserLogin, AuthResponse } from './types';
+import { setToken, setRefreshToken, removeToken } from './tokenService';
+import type { User, UserRegistration, UserLogin, AuthResponse, Session, UpdateProfileRequest, ChangePasswordRequest } from './types';
 
 /**
  * Registers a new user.
@@ -12,21 +12,69 @@ export const register = async (registrationData: UserRegistration): Promise<User
 };
 
 /**
- * Logs in a user and stores the received token.
+ * Logs in a user and stores the received tokens.
  */
 export const login = async (credentials: UserLogin): Promise<AuthResponse> => {
   const response = await apiClient.post<AuthResponse>('/auth/login', credentials);
   if (response.data?.token) {
     setToken(response.data.token);
+    setRefreshToken(response.data.refreshToken);
   }
   return response.data;
 };
 
 /**
- * Logs out the user by removing the token.
+ * Logs out the user, revoking the session on the server before removing the tokens.
  */
-export const logout = (): void => {
-  removeToken();
+export const logout = async (): Promise<void> => {
+  try {
+    await apiClient.post('/auth/logout');
+  } finally {
+    removeToken();
+  }
+};
+
+/**
+ * Confirms the user's email address with the token from the verification email.
+ */
+export const verifyEmail = async (token: string): Promise<void> => {
+  await apiClient.post('/auth/verify-email', { token });
+};
+
+/**
+ * Sends the logged-in user a new verification email.
+ */
+export const resendVerificationEmail = async (): Promise<void> => {
+  await apiClient.post('/auth/verify-email/resend');
+};
+
+/**
+ * Requests a password reset link. Succeeds whether or not the email has an account.
+ */
+export const forgotPassword = async (email: string): Promise<void> => {
+  await apiClient.post('/auth/forgot-password', { email });
+};
+
+/**
+ * Sets a new password with the token from a reset email. Logs the user out everywhere.
+ */
+export const resetPassword = async (token: string, password: string): Promise<void> => {
+  await apiClient.post('/auth/reset-password', { token, password });
+};
+
+/**
+ * Lists the devices the user is logged in on.
+ */
+export const getSessions = async (): Promise<Session[]> => {
+  const response = await apiClient.get<Session[]>('/auth/sessions');
+  return response.data;
+};
+
+/**
+ * Logs one of the user's devices out.
+ */
+export const revokeSession = async (sessionId: string): Promise<void> => {
+  await apiClient.delete(`/auth/sessions/${sessionId}`);
 };
 
 /**
@@ -36,3 +84,18 @@ export const getMyProfile = async (): Promise<User> => {
   const response = await apiClient.get<User>('/auth/me');
   return response.data;
 };
+
+/**
+ * Changes the logged-in user's username and/or email. A new email stays pending until it is confirmed.
+ */
+export const updateMyProfile = async (changes: UpdateProfileRequest): Promise<User> => {
+  const response = await apiClient.put<User>('/auth/me', changes);
+  return response.data;
+};
+
+/**
+ * Changes the logged-in user's password. Other devices are logged out.
+ */
+export const changeMyPassword = async (passwords: ChangePasswordRequest): Promise<void> => {
+  await apiClient.put('/auth/me/password', passwords);
+};
@@ -1,5 +1,6 @@
 
 const TOKEN_KEY = 'joblog_jwt';
+const REFRESH_TOKEN_KEY = 'joblog_refresh_token';
 
 /**
  * Saves the user's JWT to localStorage.
@@ -18,8 +19,25 @@ export const getToken = (): string | null => {
 };
 
 /**
- * Removes the user's JWT from localStorage (for logout).
+ * Saves the refresh token used to get a new JWT once the current one expires.
+ * @param token - The refresh token received from the login or refresh endpoint.
+ */
+export const setRefreshToken = (token: string): void => {
+  localStorage.setItem(REFRESH_TOKEN_KEY, token);
+};
+
+/**
+ * Retrieves the refresh token from localStorage.
+ * @returns The refresh token, or null if it's not found.
+ */
+export const getRefreshToken = (): string | null => {
+  return localStorage.getItem(REFRESH_TOKEN_KEY);
+};
+
+/**
+ * Removes the user's JWT and refresh token from localStorage (for logout).
  */
 export const removeToken = (): void => {
   localStorage.removeItem(TOKEN_KEY);
+  localStorage.removeItem(REFRESH_TOKEN_KEY);
 };
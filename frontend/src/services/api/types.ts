@@ -1,6 +1,9 @@
 
 // A string literal union is often more flexible than a TypeScript enum
-export type ApplicationStatus = "Applied" | "Interviewing" | "Offer" | "Rejected" | "Archived";
+// Statuses of the default workflow; users can define their own, so treat this as open-ended.
+export type ApplicationStatus =
+  | "Applied" | "Phone Screen" | "Take-home" | "Interviewing" | "Onsite"
+  | "Offer" | "Rejected" | "Withdrawn" | "Ghosted" | "Archived";
 
 // |--- User & Auth Types ---
 
@@ -8,6 +11,8 @@ export interface User {
   id: string;
   username: string;
   email: string;
+  emailVerified: boolean;
+  pendingEmail?: string; // Replaces email once the link sent to it is opened
 }
 
 export interface UserRegistration {
@@ -22,10 +27,33 @@ export interface UserLogin {
 }
 
 export interface AuthResponse {
-  token: string;
+  token: string; // Short-lived access token
+  tokenExpiresAt: string;
+  refreshToken: string; // Single use; each refresh returns a new one
   user: User;
 }
 
+export interface UpdateProfileRequest {
+  username?: string;
+  email?: string; // Only takes effect once the link sent to the new address is opened
+  currentPassword?: string; // Required to change the email
+}
+
+export interface ChangePasswordRequest {
+  currentPassword: string;
+  newPassword: string;
+}
+
+export interface Session {
+  id: string;
+  userAgent: string;
+  ip: string;
+  createdAt: string;
+  lastUsedAt: string;
+  expiresAt: string;
+  current: boolean;
+}
+
 // |--- Application Types ---
 
 export interface Note {
@@ -34,31 +62,173 @@ export interface Note {
   createdAt: string; // ISO 8601 date string
 }
 
+export type HistoryEventType = "created" | "status_changed" | "field_edited" | "note_added" | "archived" | "restored";
+
 export interface HistoryEvent {
   date: string; // ISO 8601 date string
-  event: string;
+  type: HistoryEventType;
+  fromStatus?: ApplicationStatus;
+  toStatus?: ApplicationStatus;
+  fields?: string[]; // Changed fields, for "field_edited" events
+  reason?: string;
+  event: string; // Human readable summary
+}
+
+export type WorkMode = 'remote' | 'hybrid' | 'onsite';
+export type EmploymentType = 'full_time' | 'part_time' | 'contract' | 'internship' | 'temporary';
+export type ApplicationSource = 'linkedin' | 'referral' | 'company_site' | 'job_board' | 'recruiter' | 'other';
+export type Priority = 'low' | 'medium' | 'high';
+
+// Optional details about the job posting. Omitted from responses when unset.
+export interface JobPosting {
+  jobUrl?: string;
+  location?: string;
+  workMode?: WorkMode;
+  salaryMin?: number;
+  salaryMax?: number;
+  salaryCurrency?: string; // ISO 4217, required when a salary is given
+  employmentType?: EmploymentType;
+  source?: ApplicationSource;
+  priority?: Priority;
+  jobDescription?: string;
+}
+
+// Custom field values keyed by field key: strings for text, enum and date ("YYYY-MM-DD")
+// fields, numbers and booleans for the others.
+export type CustomFieldValues = Record<string, string | number | boolean>;
+
+export interface Tag {
+  id: string;
+  name: string;
+  color: string; // "#rrggbb"
 }
 
-export interface Application {
+export interface Application extends JobPosting {
   id: string;
   company: string;
+  companyId: string;
   role: string;
   date: string; // "YYYY-MM-DD"
   updatedAt: string; // "YYYY-MM-DD"
   status: ApplicationStatus;
+  customFields: CustomFieldValues;
+  tags: Tag[];
   notes: Note[];
   history: HistoryEvent[];
 }
 
-export interface NewApplication {
+export interface NewApplication extends JobPosting {
   company: string;
   role: string;
   date: string; // "YYYY-MM-DD"
   status: ApplicationStatus;
+  customFields?: CustomFieldValues;
 }
 
 // Use Partial<T> for update types to make all fields optional
-export type ApplicationUpdate = Partial<NewApplication>;
+export type ApplicationUpdate = Partial<NewApplication> & {
+  statusReason?: string; // Required by the workflow for some transitions
+};
+
+export interface WorkflowTransition {
+  from: ApplicationStatus;
+  to: ApplicationStatus;
+  requiresReason?: boolean;
+}
+
+export interface Workflow {
+  statuses: ApplicationStatus[];
+  transitions: WorkflowTransition[];
+}
+
+export interface WeeklyCount {
+  weekStart: string; // "YYYY-MM-DD" (Monday)
+  count: number;
+}
+
+export interface ApplicationStats {
+  total: number;
+  active: number; // Everything except Archived
+  statusBreakdown: Partial<Record<ApplicationStatus, number>>;
+  responseRate: number; // 0..1, share of active applications that got a response
+  applicationsPerWeek: WeeklyCount[];
+}
+
+export type BulkOperation = 'set_status' | 'archive' | 'restore' | 'add_tag' | 'delete';
+
+export interface BulkRequest {
+  ids: string[];
+  operation: BulkOperation;
+  status?: ApplicationStatus; // For set_status
+  statusReason?: string;
+  tagId?: string; // For add_tag
+}
+
+export interface BulkResult {
+  applied: boolean; // false when any item failed; nothing was changed then
+  results: { id: string; status: 'updated' | 'deleted' | 'unchanged' | 'failed'; error?: string }[];
+}
+
+export interface ImportRequest {
+  csv: string; // Including the header row
+  fileName?: string;
+  mapping?: Partial<Record<'company' | 'role' | 'date' | 'status' | 'jobUrl' | 'location' | 'notes', string>>; // Field -> CSV header
+  dateFormat?: string; // e.g. "DD/MM/YYYY"; detected when omitted
+  statusMap?: Record<string, ApplicationStatus>;
+  dryRun?: boolean;
+  force?: boolean; // Import likely duplicates too
+}
+
+export interface ImportRow {
+  line: number;
+  company: string;
+  role: string;
+  date: string;
+  status: ApplicationStatus;
+  result: 'valid' | 'duplicate' | 'invalid';
+  errors?: string[];
+  duplicates?: string[]; // Application IDs, or "line N"
+  applicationId?: string;
+}
+
+export interface ImportResult {
+  dryRun: boolean;
+  imported: boolean;
+  dateFormat: string;
+  rows: ImportRow[];
+  created: number;
+  skipped: number;
+}
+
+// GET /applications/export?format=json; restored with POST /applications/import/backup
+export interface Backup {
+  version: number;
+  exportedAt: string; // ISO 8601 date string
+  tags: Tag[];
+  customFields: unknown[]; // Custom field definitions
+  applications: Application[];
+}
+
+export interface RestoreResult {
+  applications: number;
+  tags: number; // Created; existing tags with the same name are reused
+  customFields: number;
+}
+
+export interface SearchMatch {
+  source: 'application' | 'note' | 'history';
+  noteId?: string;
+  snippet: string; // HTML: the text is escaped and matched words are wrapped in <mark></mark>
+}
+
+export interface SearchResult {
+  applicationId: string;
+  company: string;
+  role: string;
+  status: ApplicationStatus;
+  rank: number;
+  matches: SearchMatch[];
+}
 
 
 // |--- Blog Types ---
@@ -110,17 +110,6 @@ export interface CreateBlogPostData {
   coverImage?: string;
 }
 
-export interface DashboardStats {
-  totalApplications: number;
-  interviewing: number;
-  offers: number;
-  recentApplications: Application[];
-  recentBlogPosts: BlogPost[];
-  statusBreakdown: {
-    [key in Application['status']]: number;
-  };
-}
-
 export interface ApiResponse<T> {
   data: T;
   message?: string;